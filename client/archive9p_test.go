@@ -0,0 +1,176 @@
+// Copyright 2023 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestTar(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	now := time.Unix(1700000000, 0)
+	for _, e := range []struct {
+		name string
+		dir  bool
+		body string
+	}{
+		{name: "b", dir: true},
+		{name: "b/c", dir: true},
+		{name: "b/c/hi", body: "hi\n"},
+	} {
+		hdr := &tar.Header{Name: e.name, ModTime: now}
+		if e.dir {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0o755
+		} else {
+			hdr.Typeflag = tar.TypeReg
+			hdr.Mode = 0o644
+			hdr.Size = int64(len(e.body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if !e.dir {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTAR9P(t *testing.T) {
+	d := t.TempDir()
+	p := filepath.Join(d, "test.tar")
+	writeTestTar(t, p)
+
+	fs, err := NewTAR9P(p)
+	if err != nil {
+		t.Fatalf("NewTAR9P: got %v, want nil", err)
+	}
+	attach, err := fs.Attach()
+	if err != nil {
+		t.Fatalf("Attach: got %v, want nil", err)
+	}
+	_, root, err := attach.Walk([]string{})
+	if err != nil {
+		t.Fatalf("walking '': want nil, got %v", err)
+	}
+	_, hi, err := root.Walk([]string{"b", "c", "hi"})
+	if err != nil {
+		t.Fatalf("walking b/c/hi: want nil, got %v", err)
+	}
+	var buf [3]byte
+	n, err := hi.ReadAt(buf[:], 0)
+	if err != nil && err.Error() != "EOF" {
+		t.Fatalf("ReadAt: got %v, want nil", err)
+	}
+	if string(buf[:n]) != "hi\n" {
+		t.Fatalf("ReadAt: got %q, want %q", buf[:n], "hi\n")
+	}
+}
+
+func writeTestZip(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("b/c/hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("hi\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestZip9P(t *testing.T) {
+	d := t.TempDir()
+	p := filepath.Join(d, "test.zip")
+	writeTestZip(t, p)
+
+	fs, err := NewZip9P(p)
+	if err != nil {
+		t.Fatalf("NewZip9P: got %v, want nil", err)
+	}
+	attach, err := fs.Attach()
+	if err != nil {
+		t.Fatalf("Attach: got %v, want nil", err)
+	}
+	_, root, err := attach.Walk([]string{})
+	if err != nil {
+		t.Fatalf("walking '': want nil, got %v", err)
+	}
+	_, hi, err := root.Walk([]string{"b", "c", "hi"})
+	if err != nil {
+		t.Fatalf("walking b/c/hi: want nil, got %v", err)
+	}
+	var buf [3]byte
+	n, _ := hi.ReadAt(buf[:], 0)
+	if string(buf[:n]) != "hi\n" {
+		t.Fatalf("ReadAt: got %q, want %q", buf[:n], "hi\n")
+	}
+}
+
+func TestMultiAttacher(t *testing.T) {
+	d := t.TempDir()
+	lowPath := filepath.Join(d, "low.tar")
+	writeTestTar(t, lowPath)
+	low, err := NewTAR9P(lowPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	highPath := filepath.Join(d, "high.zip")
+	writeTestZip(t, highPath)
+	high, err := NewZip9P(highPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMultiAttacher(high, low)
+	attach, err := m.Attach()
+	if err != nil {
+		t.Fatalf("Attach: got %v, want nil", err)
+	}
+	_, root, err := attach.Walk([]string{})
+	if err != nil {
+		t.Fatalf("walking '': want nil, got %v", err)
+	}
+
+	// Both backends agree on b/c/hi, but it should resolve via the
+	// higher-precedence zip attacher without error either way.
+	if _, _, err := root.Walk([]string{"b", "c", "hi"}); err != nil {
+		t.Fatalf("walking b/c/hi: want nil, got %v", err)
+	}
+
+	dirs, err := root.Readdir(0, 64*1024)
+	if err != nil {
+		t.Fatalf("readdir on root: want nil, got %v", err)
+	}
+	// "." plus "b" merged from both attachers into one entry.
+	if len(dirs) != 2 {
+		t.Fatalf("readdir on root: want 2 entries, got %d: %v", len(dirs), dirs)
+	}
+}
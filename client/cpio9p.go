@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -24,6 +25,27 @@ import (
 	"github.com/u-root/u-root/pkg/cpio"
 )
 
+// upperEntry is one writable entry in the overlay upper layer that
+// NewWritableCPIO9P layers on top of the immutable cpio records: a
+// copied-up file, or a brand new file, directory, or symlink created
+// since the archive was opened.
+type upperEntry struct {
+	info cpio.Info
+	data []byte // regular file contents.
+	link string // symlink target; only set for S_IFLNK entries.
+}
+
+func (u *upperEntry) readAt(p []byte, offset int64) (int, error) {
+	if offset < 0 || offset > int64(len(u.data)) {
+		return 0, os.ErrInvalid
+	}
+	n := copy(p, u.data[offset:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
 // CPIO9P is a p9.Attacher.
 type CPIO9P struct {
 	p9.DefaultWalkGetAttr
@@ -32,6 +54,16 @@ type CPIO9P struct {
 	rr   cpio.RecordReader
 	m    map[string]uint64
 	recs []cpio.Record
+
+	// writable, when set, layers an in-memory overlayfs-style upper
+	// directory over recs: paths below len(recs) are the immutable
+	// base layer, and paths at or above it index into upper. The
+	// first write to a base path copies it up into upper and
+	// redirects m to the copy; reads, writes, and attribute changes
+	// from then on are served from there.
+	writable bool
+	upper    []upperEntry
+	whiteout map[string]bool
 }
 
 // CPIO9PFile defines a FID.
@@ -78,6 +110,21 @@ func NewCPIO9P(c string) (*CPIO9P, error) {
 	return &CPIO9P{file: f, rr: rr, recs: recs, m: m}, nil
 }
 
+// NewWritableCPIO9P is like NewCPIO9P, but the returned CPIO9P serves
+// a writable root: Create, Mkdir, Symlink, WriteAt, SetAttr, and
+// UnlinkAt all succeed, landing in an in-memory upper layer instead of
+// the read-only archive. Call Commit to serialize the current overlay
+// view back out as a new cpio archive.
+func NewWritableCPIO9P(c string) (*CPIO9P, error) {
+	s, err := NewCPIO9P(c)
+	if err != nil {
+		return nil, err
+	}
+	s.writable = true
+	s.whiteout = map[string]bool{}
+	return s, nil
+}
+
 // Attach implements p9.Attacher.Attach.
 // Only works for root.
 func (s *CPIO9P) Attach() (p9.File, error) {
@@ -89,24 +136,101 @@ var (
 	_ p9.Attacher = &CPIO9P{}
 )
 
-func (l *CPIO9PFID) rec() (*cpio.Record, error) {
-	if int(l.path) > len(l.fs.recs) {
-		return nil, os.ErrNotExist
+// infoAt returns the cpio.Info for path, whichever layer it lives in.
+func (s *CPIO9P) infoAt(path uint64) (cpio.Info, error) {
+	if path < uint64(len(s.recs)) {
+		return s.recs[path].Info, nil
+	}
+	i := int(path) - len(s.recs)
+	if i < 0 || i >= len(s.upper) {
+		return cpio.Info{}, os.ErrNotExist
+	}
+	return s.upper[i].info, nil
+}
+
+// readAt reads from path, whichever layer it lives in.
+func (s *CPIO9P) readAt(path uint64, p []byte, offset int64) (int, error) {
+	if path < uint64(len(s.recs)) {
+		return s.recs[path].ReadAt(p, offset)
+	}
+	i := int(path) - len(s.recs)
+	if i < 0 || i >= len(s.upper) {
+		return 0, os.ErrNotExist
 	}
-	v("rec for %v is %v", l, l.fs.recs[l.path])
-	return &l.fs.recs[l.path], nil
+	return s.upper[i].readAt(p, offset)
+}
+
+// Commit serializes the current overlay view -- the base archive with
+// whiteouts removed and the upper layer's adds, copy-ups, and edits
+// applied -- out to w as a new newc cpio archive. It is only valid on
+// a CPIO9P returned by NewWritableCPIO9P.
+func (s *CPIO9P) Commit(w io.Writer) error {
+	if !s.writable {
+		return os.ErrInvalid
+	}
+	archive, err := cpio.Format("newc")
+	if err != nil {
+		return err
+	}
+	rw := archive.Writer(w)
+	for name, idx := range s.m {
+		if s.whiteout[name] {
+			continue
+		}
+		if idx < uint64(len(s.recs)) {
+			if err := rw.WriteRecord(s.recs[idx]); err != nil {
+				return err
+			}
+			continue
+		}
+		u := s.upper[idx-uint64(len(s.recs))]
+		var rec cpio.Record
+		if u.link != "" {
+			rec = cpio.Symlink(u.info.Name, u.link)
+		} else {
+			rec = cpio.StaticRecord(u.data, u.info)
+		}
+		if err := rw.WriteRecord(rec); err != nil {
+			return err
+		}
+	}
+	return cpio.WriteTrailer(rw)
+}
+
+// copyUp materializes l's path into the writable upper layer if it is
+// not already there: the base record's full contents are read and
+// copied into a new upper entry, and the name->path mapping at fs.m
+// is redirected to the copy. l.path is updated to the copy so the
+// rest of this call can serve the write from it. A no-op if l's path
+// is already in the upper layer.
+func (l *CPIO9PFID) copyUp() error {
+	base := uint64(len(l.fs.recs))
+	if l.path >= base {
+		return nil
+	}
+	r := l.fs.recs[l.path]
+	data := make([]byte, r.Info.FileSize)
+	if len(data) > 0 {
+		if _, err := r.ReadAt(data, 0); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	l.fs.upper = append(l.fs.upper, upperEntry{info: r.Info, data: data})
+	idx := base + uint64(len(l.fs.upper)-1)
+	l.fs.m[r.Info.Name] = idx
+	l.path = idx
+	return nil
 }
 
 // info constructs a QID for this file.
 func (l *CPIO9PFID) info() (p9.QID, *cpio.Info, error) {
 	var qid p9.QID
 
-	r, err := l.rec()
+	fi, err := l.fs.infoAt(l.path)
 	if err != nil {
 		return qid, nil, err
 	}
 
-	fi := r.Info
 	// Construct the QID type.
 	//qid.Type = p9.ModeFromOS(fi.Mode).QIDType()
 
@@ -117,11 +241,11 @@ func (l *CPIO9PFID) info() (p9.QID, *cpio.Info, error) {
 
 // Walk implements p9.File.Walk.
 func (l *CPIO9PFID) Walk(names []string) ([]p9.QID, p9.File, error) {
-	r, err := l.rec()
+	fi, err := l.fs.infoAt(l.path)
 	if err != nil {
 		return nil, nil, err
 	}
-	verbose("starting record for %v is %v", l, r)
+	verbose("starting record for %v is %v", l, fi)
 	var qids []p9.QID
 	last := &CPIO9PFID{path: l.path, fs: l.fs}
 	// If the names are empty we return info for l
@@ -140,7 +264,7 @@ func (l *CPIO9PFID) Walk(names []string) ([]p9.QID, p9.File, error) {
 		return qids, last, nil
 	}
 	verbose("Walk: %v", names)
-	fullpath := r.Info.Name
+	fullpath := fi.Name
 	verbose("Walk from %q: %q", fullpath, names)
 	for _, name := range names {
 		c := &CPIO9PFID{path: last.path, fs: l.fs}
@@ -149,8 +273,11 @@ func (l *CPIO9PFID) Walk(names []string) ([]p9.QID, p9.File, error) {
 			return nil, nil, err
 		}
 		fullpath = filepath.Join(fullpath, name)
+		if l.fs.whiteout[fullpath] {
+			return nil, nil, os.ErrNotExist
+		}
 		ix, ok := l.fs.m[fullpath]
-		verbose("Walk to %q from %v: %v, %v, %v", fullpath, r, qid, fi, ok)
+		verbose("Walk to %q from %v: %v, %v, %v", fullpath, fi, qid, fi, ok)
 		if !ok {
 			return nil, nil, os.ErrNotExist
 		}
@@ -179,7 +306,7 @@ func (l *CPIO9PFID) Open(mode p9.OpenFlags) (p9.QID, uint32, error) {
 		return qid, 0, err
 	}
 
-	if mode != p9.ReadOnly {
+	if mode != p9.ReadOnly && !l.fs.writable {
 		return qid, 0, os.ErrPermission
 	}
 
@@ -192,35 +319,106 @@ func (l *CPIO9PFID) Open(mode p9.OpenFlags) (p9.QID, uint32, error) {
 
 // Read implements p9.File.ReadAt.
 func (l *CPIO9PFID) ReadAt(p []byte, offset int64) (int, error) {
-	r, err := l.rec()
-	if err != nil {
-		return -1, err
-	}
-	return r.ReadAt(p, offset)
+	return l.fs.readAt(l.path, p, offset)
 }
 
-// Write implements p9.File.WriteAt.
+// WriteAt implements p9.File.WriteAt. On a writable CPIO9P, the first
+// write to a path copies it up into the upper layer; all further
+// reads and writes to that path are then served from the copy.
 func (l *CPIO9PFID) WriteAt(p []byte, offset int64) (int, error) {
-	return -1, os.ErrPermission
+	if !l.fs.writable {
+		return -1, os.ErrPermission
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	if err := l.copyUp(); err != nil {
+		return -1, err
+	}
+	u := &l.fs.upper[l.path-uint64(len(l.fs.recs))]
+	end := offset + int64(len(p))
+	if end > int64(len(u.data)) {
+		grown := make([]byte, end)
+		copy(grown, u.data)
+		u.data = grown
+	}
+	n := copy(u.data[offset:], p)
+	u.info.FileSize = uint64(len(u.data))
+	return n, nil
 }
 
 // Create implements p9.File.Create.
-func (l *CPIO9PFID) Create(name string, mode p9.OpenFlags, permissions p9.FileMode, _ p9.UID, _ p9.GID) (p9.File, p9.QID, uint32, error) {
-	return nil, p9.QID{}, 0, os.ErrPermission
+func (l *CPIO9PFID) Create(name string, mode p9.OpenFlags, permissions p9.FileMode, uid p9.UID, gid p9.GID) (p9.File, p9.QID, uint32, error) {
+	if !l.fs.writable {
+		return nil, p9.QID{}, 0, os.ErrPermission
+	}
+	idx, err := l.fs.addUpper(l.path, name, cpio.Info{
+		Mode:  uint64(permissions) | uint64(syscall.S_IFREG),
+		UID:   uint64(uid),
+		GID:   uint64(gid),
+		NLink: 1,
+	}, nil, "")
+	if err != nil {
+		return nil, p9.QID{}, 0, err
+	}
+	child := &CPIO9PFID{path: idx, fs: l.fs}
+	qid, _, err := child.info()
+	return child, qid, 0, err
 }
 
 // Mkdir implements p9.File.Mkdir.
-//
-// Not properly implemented.
-func (l *CPIO9PFID) Mkdir(name string, permissions p9.FileMode, _ p9.UID, _ p9.GID) (p9.QID, error) {
-	return p9.QID{}, os.ErrPermission
+func (l *CPIO9PFID) Mkdir(name string, permissions p9.FileMode, uid p9.UID, gid p9.GID) (p9.QID, error) {
+	if !l.fs.writable {
+		return p9.QID{}, os.ErrPermission
+	}
+	idx, err := l.fs.addUpper(l.path, name, cpio.Info{
+		Mode:  uint64(permissions) | uint64(syscall.S_IFDIR),
+		UID:   uint64(uid),
+		GID:   uint64(gid),
+		NLink: 2,
+	}, nil, "")
+	if err != nil {
+		return p9.QID{}, err
+	}
+	return p9.QID{Path: idx}, nil
 }
 
 // Symlink implements p9.File.Symlink.
-//
-// Not properly implemented.
-func (l *CPIO9PFID) Symlink(oldname string, newname string, _ p9.UID, _ p9.GID) (p9.QID, error) {
-	return p9.QID{}, os.ErrPermission
+func (l *CPIO9PFID) Symlink(oldname string, newname string, uid p9.UID, gid p9.GID) (p9.QID, error) {
+	if !l.fs.writable {
+		return p9.QID{}, os.ErrPermission
+	}
+	idx, err := l.fs.addUpper(l.path, newname, cpio.Info{
+		Mode:     0777 | uint64(syscall.S_IFLNK),
+		UID:      uint64(uid),
+		GID:      uint64(gid),
+		NLink:    1,
+		FileSize: uint64(len(oldname)),
+	}, nil, oldname)
+	if err != nil {
+		return p9.QID{}, err
+	}
+	return p9.QID{Path: idx}, nil
+}
+
+// addUpper adds a new upper-layer entry named name under the
+// directory at dirPath, failing if a live entry of that name already
+// exists. It is the shared tail of Create, Mkdir, and Symlink.
+func (s *CPIO9P) addUpper(dirPath uint64, name string, info cpio.Info, data []byte, link string) (uint64, error) {
+	dir, err := s.infoAt(dirPath)
+	if err != nil {
+		return 0, err
+	}
+	fullpath := filepath.Join(dir.Name, name)
+	if _, ok := s.m[fullpath]; ok && !s.whiteout[fullpath] {
+		return 0, os.ErrExist
+	}
+	info.Name = fullpath
+	idx := uint64(len(s.recs)) + uint64(len(s.upper))
+	s.upper = append(s.upper, upperEntry{info: info, data: data, link: link})
+	s.m[fullpath] = idx
+	delete(s.whiteout, fullpath)
+	return idx, nil
 }
 
 // Link implements p9.File.Link.
@@ -232,30 +430,29 @@ func (l *CPIO9PFID) Link(target p9.File, newname string) error {
 
 func (l *CPIO9PFID) readdir() ([]uint64, error) {
 	verbose("readdir at %d", l.path)
-	r, err := l.rec()
+	fi, err := l.fs.infoAt(l.path)
 	if err != nil {
 		return nil, err
 	}
-	dn := r.Info.Name
-	verbose("readdir starts from %v %v", l, r)
-	// while the name is a prefix of the records we are scanning,
-	// append the record.
-	// This can not be returned as a range as we do not want
-	// contents of all subdirs.
+	dn := fi.Name
+	verbose("readdir starts from %v %v", l, fi)
+	// Walk every name the overlay currently knows about -- base
+	// records plus upper adds and copy-ups -- and keep the ones
+	// that are direct children of dn and not whited out.
 	var list []uint64
-	for i, r := range l.fs.recs[l.path+1:] {
-		// filepath.Rel fails, we're done here.
-		b, err := filepath.Rel(dn, r.Name)
+	for name, idx := range l.fs.m {
+		if name == dn || l.fs.whiteout[name] {
+			continue
+		}
+		b, err := filepath.Rel(dn, name)
 		if err != nil {
-			verbose("r.Name %q: DONE", r.Name)
-			break
+			continue
 		}
-		dir, _ := filepath.Split(b)
-		if len(dir) > 0 {
+		if dir, _ := filepath.Split(b); len(dir) > 0 {
 			continue
 		}
-		verbose("readdir: %v", i)
-		list = append(list, uint64(i)+l.path+1)
+		verbose("readdir: %v", name)
+		list = append(list, idx)
 	}
 	return list, nil
 }
@@ -288,15 +485,15 @@ func (l *CPIO9PFID) Readdir(offset uint64, count uint32) (p9.Dirents, error) {
 		if err != nil {
 			continue
 		}
-		r, err := entry.rec()
+		fi, err := l.fs.infoAt(i)
 		if err != nil {
 			continue
 		}
-		verbose("add path %d %q", i, r.Info.Name)
+		verbose("add path %d %q", i, fi.Name)
 		dirents = append(dirents, p9.Dirent{
 			QID:    qid,
 			Type:   qid.Type,
-			Name:   r.Info.Name,
+			Name:   fi.Name,
 			Offset: i,
 		})
 	}
@@ -306,6 +503,10 @@ func (l *CPIO9PFID) Readdir(offset uint64, count uint32) (p9.Dirents, error) {
 
 // Readlink implements p9.File.Readlink.
 func (l *CPIO9PFID) Readlink() (string, error) {
+	i := int(l.path) - len(l.fs.recs)
+	if i >= 0 && i < len(l.fs.upper) && l.fs.upper[i].link != "" {
+		return l.fs.upper[i].link, nil
+	}
 	return "", os.ErrPermission
 }
 
@@ -319,8 +520,24 @@ func (l *CPIO9PFID) Renamed(parent p9.File, newName string) {
 }
 
 // UnlinkAt implements p9.File.UnlinkAt.
+//
+// The removed name is recorded as a whiteout so it stays hidden even
+// though the underlying cpio record, if any, is never mutated.
 func (l *CPIO9PFID) UnlinkAt(name string, flags uint32) error {
-	return os.ErrPermission
+	if !l.fs.writable {
+		return os.ErrPermission
+	}
+	fi, err := l.fs.infoAt(l.path)
+	if err != nil {
+		return err
+	}
+	fullpath := filepath.Join(fi.Name, name)
+	if _, ok := l.fs.m[fullpath]; !ok || l.fs.whiteout[fullpath] {
+		return os.ErrNotExist
+	}
+	delete(l.fs.m, fullpath)
+	l.fs.whiteout[fullpath] = true
+	return nil
 }
 
 // Mknod implements p9.File.Mknod.
@@ -346,8 +563,41 @@ func (*CPIO9PFID) StatFS() (p9.FSStat, error) {
 	return p9.FSStat{}, syscall.ENOSYS
 }
 
+// SetAttr implements p9.File.SetAttr. On a writable CPIO9P it copies
+// the path up into the upper layer, if it is not already there, and
+// applies the requested changes to the copy.
 func (l *CPIO9PFID) SetAttr(mask p9.SetAttrMask, attr p9.SetAttr) error {
-	return os.ErrPermission
+	if !l.fs.writable {
+		return os.ErrPermission
+	}
+	if err := l.copyUp(); err != nil {
+		return err
+	}
+	u := &l.fs.upper[l.path-uint64(len(l.fs.recs))]
+	if mask.Permissions {
+		u.info.Mode = (u.info.Mode &^ 0o7777) | uint64(attr.Permissions&0o7777)
+	}
+	if mask.UID {
+		u.info.UID = uint64(attr.UID)
+	}
+	if mask.GID {
+		u.info.GID = uint64(attr.GID)
+	}
+	if mask.Size {
+		switch {
+		case attr.Size < uint64(len(u.data)):
+			u.data = u.data[:attr.Size]
+		case attr.Size > uint64(len(u.data)):
+			grown := make([]byte, attr.Size)
+			copy(grown, u.data)
+			u.data = grown
+		}
+		u.info.FileSize = attr.Size
+	}
+	if mask.MTime {
+		u.info.MTime = attr.MTimeSeconds
+	}
+	return nil
 }
 
 // GetAttr implements p9.File.GetAttr.
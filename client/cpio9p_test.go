@@ -166,3 +166,80 @@ func TestCPIO9P(t *testing.T) {
 	}
 	t.Logf("readdir / %v", dirs)
 }
+
+func TestCPIO9PWritable(t *testing.T) {
+	v = t.Logf
+
+	fs, err := NewWritableCPIO9P("data/a.cpio")
+	if err != nil {
+		t.Fatalf("data/a.cpio: got %v, want nil", err)
+	}
+
+	attach, err := fs.Attach()
+	if err != nil {
+		t.Fatalf("Attach: got %v, want nil", err)
+	}
+	_, root, err := attach.Walk([]string{})
+	if err != nil {
+		t.Fatalf("walking '': want nil, got %v", err)
+	}
+
+	var (
+		of p9.OpenFlags
+		m  p9.FileMode
+	)
+
+	// Create a new file and write to it; the write lands in the
+	// upper layer and reads it right back.
+	newFile, _, _, err := root.Create("new", of, m, p9.UID(0), p9.GID(0))
+	if err != nil {
+		t.Fatalf("create in writable root: got %v, want nil", err)
+	}
+	if n, err := newFile.WriteAt([]byte("hello"), 0); err != nil || n != 5 {
+		t.Fatalf("WriteAt new: got (%d, %v), want (5, nil)", n, err)
+	}
+	var buf [5]byte
+	if n, err := newFile.ReadAt(buf[:], 0); err != nil || string(buf[:n]) != "hello" {
+		t.Fatalf("ReadAt new: got (%q, %v), want (%q, nil)", buf[:n], err, "hello")
+	}
+
+	// Creating the same name again should fail.
+	if _, _, _, err := root.Create("new", of, m, p9.UID(0), p9.GID(0)); err == nil {
+		t.Errorf("re-create in writable root: got nil, want err")
+	}
+
+	// A base-layer file is copy-up-on-write.
+	_, hi, err := root.Walk([]string{"b", "c", "hi"})
+	if err != nil {
+		t.Fatalf("walking b/c/hi: want nil, got %v", err)
+	}
+	if _, err := hi.WriteAt([]byte("HI"), 0); err != nil {
+		t.Fatalf("WriteAt hi: want nil, got %v", err)
+	}
+	var hibuf [2]byte
+	if _, err := hi.ReadAt(hibuf[:], 0); err != nil || string(hibuf[:]) != "HI" {
+		t.Fatalf("ReadAt hi: got (%q, %v), want (%q, nil)", hibuf[:], err, "HI")
+	}
+
+	if _, err := root.Mkdir("d", m, p9.UID(0), p9.GID(0)); err != nil {
+		t.Fatalf("mkdir in writable root: want nil, got %v", err)
+	}
+
+	if _, err := root.Symlink("new", "link", p9.UID(0), p9.GID(0)); err != nil {
+		t.Fatalf("symlink in writable root: want nil, got %v", err)
+	}
+	_, link, err := root.Walk([]string{"link"})
+	if err != nil {
+		t.Fatalf("walking link: want nil, got %v", err)
+	}
+	if target, err := link.Readlink(); err != nil || target != "new" {
+		t.Fatalf("readlink: got (%q, %v), want (%q, nil)", target, err, "new")
+	}
+
+	if err := root.UnlinkAt("new", 0); err != nil {
+		t.Fatalf("unlink new: want nil, got %v", err)
+	}
+	if _, _, err := root.Walk([]string{"new"}); err == nil {
+		t.Errorf("walking unlinked new: got nil, want err")
+	}
+}
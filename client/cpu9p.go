@@ -15,7 +15,7 @@
 package client
 
 import (
-	"io/ioutil"
+	"io"
 	"log"
 	"os"
 	"path"
@@ -24,6 +24,7 @@ import (
 	"syscall"
 
 	"github.com/hugelgupf/p9/p9"
+	"golang.org/x/sys/unix"
 )
 
 // CPU9P is a p9.Attacher.
@@ -131,7 +132,12 @@ func (l *CPU9P) Open(mode p9.OpenFlags) (p9.QID, uint32, error) {
 		return qid, 0, err
 	}
 
-	flags := osflags(fi, mode)
+	// O_NOFOLLOW guards against the path being swapped for a symlink
+	// between the Walk that produced qid/fi and this Open; a 9p client
+	// is supposed to Readlink a symlink QID, never Open it, so there's
+	// never a legitimate reason for the final component to have become
+	// one in between.
+	flags := osflags(fi, mode) | unix.O_NOFOLLOW
 	// Do the actual open.
 	f, err := os.OpenFile(l.path, flags, 0)
 	verbose("Open(%v, %v, %v): (%v, %v", l.path, flags, 0, f, err)
@@ -188,27 +194,25 @@ func (l *CPU9P) Create(name string, mode p9.OpenFlags, permissions p9.FileMode,
 }
 
 // Mkdir implements p9.File.Mkdir.
-//
-// Not properly implemented.
 func (l *CPU9P) Mkdir(name string, permissions p9.FileMode, _ p9.UID, _ p9.GID) (p9.QID, error) {
-	if err := os.Mkdir(filepath.Join(l.path, name), os.FileMode(permissions)); err != nil {
+	p := filepath.Join(l.path, name)
+	if err := os.Mkdir(p, os.FileMode(permissions)); err != nil {
 		return p9.QID{}, err
 	}
 
-	// Blank QID.
-	return p9.QID{}, nil
+	qid, _, err := (&CPU9P{path: p}).info()
+	return qid, err
 }
 
 // Symlink implements p9.File.Symlink.
-//
-// Not properly implemented.
 func (l *CPU9P) Symlink(oldname string, newname string, _ p9.UID, _ p9.GID) (p9.QID, error) {
-	if err := os.Symlink(oldname, filepath.Join(l.path, newname)); err != nil {
+	p := filepath.Join(l.path, newname)
+	if err := os.Symlink(oldname, p); err != nil {
 		return p9.QID{}, err
 	}
 
-	// Blank QID.
-	return p9.QID{}, nil
+	qid, _, err := (&CPU9P{path: p}).info()
+	return qid, err
 }
 
 // Link implements p9.File.Link.
@@ -218,25 +222,51 @@ func (l *CPU9P) Link(target p9.File, newname string) error {
 	return os.Link(target.(*CPU9P).path, filepath.Join(l.path, newname))
 }
 
-// Readdir implements p9.File.Readdir.
+// readdirBatch is how many entries Readdir asks the kernel for per
+// call. It bounds each 9P readdir round trip to a useful chunk instead
+// of either the whole directory (the old behavior, O(N^2) over a
+// directory of any size since every call restarted the scan) or one
+// RPC per entry.
+const readdirBatch = 256
+
+// Readdir implements p9.File.Readdir. It reads through l.file (opened
+// once by Open) in batches rather than re-listing the whole directory
+// on every call: offset 0 rewinds (a client starting the listing over),
+// any other offset just continues consuming l.file's own directory
+// cursor, which os.File.ReadDir advances for us. Each entry still costs
+// one Lstat, to get the inode number for QID.Path; d_type alone (which
+// a raw getdents(2) would give for free) isn't enough for that.
 func (l *CPU9P) Readdir(offset uint64, count uint32) (p9.Dirents, error) {
-	fi, err := ioutil.ReadDir(l.path)
-	if err != nil {
+	if l.file == nil {
+		return nil, os.ErrInvalid
+	}
+	if offset == 0 {
+		if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := l.file.ReadDir(readdirBatch)
+	if err != nil && err != io.EOF {
 		return nil, err
 	}
-	var dirents p9.Dirents
-	//log.Printf("readdir %q returns %d entries start at offset %d", l.path, len(fi), offset)
-	for i := int(offset); i < len(fi); i++ {
-		entry := CPU9P{path: filepath.Join(l.path, fi[i].Name())}
-		qid, _, err := entry.info()
+
+	dirents := make(p9.Dirents, 0, len(entries))
+	for i, e := range entries {
+		fi, err := e.Info()
 		if err != nil {
 			continue
 		}
+		st, ok := fi.Sys().(*syscall.Stat_t)
+		if !ok {
+			continue
+		}
+		qtype := p9.ModeFromOS(fi.Mode()).QIDType()
 		dirents = append(dirents, p9.Dirent{
-			QID:    qid,
-			Type:   qid.Type,
-			Name:   fi[i].Name(),
-			Offset: uint64(i + 1),
+			QID:    p9.QID{Type: qtype, Path: st.Ino},
+			Type:   qtype,
+			Name:   e.Name(),
+			Offset: offset + uint64(i) + 1,
 		})
 	}
 
@@ -280,15 +310,24 @@ func (l *CPU9P) UnlinkAt(name string, flags uint32) error {
 }
 
 // Mknod implements p9.File.Mknod.
-func (*CPU9P) Mknod(name string, mode p9.FileMode, major uint32, minor uint32, _ p9.UID, _ p9.GID) (p9.QID, error) {
-	verbose("Mknod: not implemented")
-	return p9.QID{}, syscall.ENOSYS
+func (l *CPU9P) Mknod(name string, mode p9.FileMode, major uint32, minor uint32, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	p := filepath.Join(l.path, name)
+	dev := int(unix.Mkdev(major, minor))
+	if err := unix.Mknod(p, uint32(mode), dev); err != nil {
+		return p9.QID{}, err
+	}
+	qid, _, err := (&CPU9P{path: p}).info()
+	return qid, err
 }
 
-// Rename implements p9.File.Rename.
-func (*CPU9P) Rename(directory p9.File, name string) error {
-	verbose("Rename: not implemented")
-	return syscall.ENOSYS
+// Rename implements p9.File.Rename: rename l itself into directory,
+// under name.
+func (l *CPU9P) Rename(directory p9.File, name string) error {
+	d, ok := directory.(*CPU9P)
+	if !ok {
+		return os.ErrInvalid
+	}
+	return os.Rename(l.path, filepath.Join(d.path, name))
 }
 
 // RenameAt implements p9.File.RenameAt.
@@ -308,9 +347,176 @@ func (l *CPU9P) RenameAt(oldName string, newDir p9.File, newName string) error {
 }
 
 // StatFS implements p9.File.StatFS.
+func (l *CPU9P) StatFS() (p9.FSStat, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(l.path, &st); err != nil {
+		return p9.FSStat{}, err
+	}
+	return p9.FSStat{
+		Type:            uint32(st.Type),
+		BlockSize:       uint32(st.Bsize),
+		Blocks:          st.Blocks,
+		BlocksFree:      st.Bfree,
+		BlocksAvailable: st.Bavail,
+		Files:           st.Files,
+		FilesFree:       st.Ffree,
+		FSID:            uint64(st.Fsid.X__val[0])<<32 | uint64(uint32(st.Fsid.X__val[1])),
+		NameLength:      uint32(st.Namelen),
+	}, nil
+}
+
+// GetAttr implements p9.File.GetAttr.
 //
-// Not implemented.
-func (*CPU9P) StatFS() (p9.FSStat, error) {
-	verbose("StatFS: not implemented")
-	return p9.FSStat{}, syscall.ENOSYS
+// Btime (creation time) comes from statx(2) on Linux, which is the
+// only way to get at it; everything else comes from the Stat_t we'd
+// have gotten from a plain Lstat anyway. req is consulted so a client
+// that only asked for cheap fields (e.g. just Mode) doesn't force us
+// to pay for a statx call it didn't need.
+func (l *CPU9P) GetAttr(req p9.AttrMask) (p9.QID, p9.AttrMask, p9.Attr, error) {
+	qid, fi, err := l.info()
+	if err != nil {
+		return qid, p9.AttrMask{}, p9.Attr{}, err
+	}
+	st := fi.Sys().(*syscall.Stat_t)
+
+	attr := p9.Attr{
+		Mode:             p9.FileMode(st.Mode),
+		UID:              p9.UID(st.Uid),
+		GID:              p9.GID(st.Gid),
+		NLink:            p9.NLink(st.Nlink),
+		RDev:             p9.Dev(st.Rdev),
+		Size:             uint64(st.Size),
+		BlockSize:        uint64(st.Blksize),
+		Blocks:           uint64(st.Blocks),
+		ATimeSeconds:     uint64(st.Atim.Sec),
+		ATimeNanoSeconds: uint64(st.Atim.Nsec),
+		MTimeSeconds:     uint64(st.Mtim.Sec),
+		MTimeNanoSeconds: uint64(st.Mtim.Nsec),
+		CTimeSeconds:     uint64(st.Ctim.Sec),
+		CTimeNanoSeconds: uint64(st.Ctim.Nsec),
+	}
+	valid := p9.AttrMask{
+		Mode:   true,
+		UID:    true,
+		GID:    true,
+		NLink:  true,
+		RDev:   true,
+		Size:   true,
+		Blocks: true,
+		ATime:  true,
+		MTime:  true,
+		CTime:  true,
+	}
+
+	if req.BTime {
+		var stx unix.Statx_t
+		if err := unix.Statx(unix.AT_FDCWD, l.path, unix.AT_SYMLINK_NOFOLLOW, unix.STATX_BTIME, &stx); err == nil && stx.Mask&unix.STATX_BTIME != 0 {
+			attr.BTimeSeconds = uint64(stx.Btime.Sec)
+			attr.BTimeNanoSeconds = uint64(stx.Btime.Nsec)
+			valid.BTime = true
+		} else {
+			// Not every filesystem tracks btime (ext4 does,
+			// tmpfs doesn't); fall back to ctime, same as most
+			// statx(2) callers do when STATX_BTIME isn't set.
+			attr.BTimeSeconds = attr.CTimeSeconds
+			attr.BTimeNanoSeconds = attr.CTimeNanoSeconds
+			valid.BTime = true
+		}
+	}
+
+	return qid, valid, attr, nil
+}
+
+// xattrNamespaces restricts which xattr namespaces GetXattr/SetXattr/
+// ListXattr/RemoveXattr will forward to the underlying filesystem.
+// Defaults to user.* only: security.*/trusted.* let a client rewrite
+// SELinux/AppArmor labels or ACLs on the host from inside what's
+// supposed to be a confined session, which is not something to hand
+// out by default. Set via SetXattrNamespaces.
+var xattrNamespaces = []string{"user."}
+
+// SetXattrNamespaces replaces the set of xattr namespace prefixes the
+// 9p xattr ops are allowed to touch. Pass nil to allow everything.
+func SetXattrNamespaces(ns []string) {
+	xattrNamespaces = ns
+}
+
+// xattrAllowed reports whether name falls under one of
+// xattrNamespaces's prefixes. A nil/empty xattrNamespaces allows
+// everything.
+func xattrAllowed(name string) bool {
+	if len(xattrNamespaces) == 0 {
+		return true
+	}
+	for _, ns := range xattrNamespaces {
+		if strings.HasPrefix(name, ns) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetXattr implements the 9P2000.L xattr extension via Lgetxattr.
+func (l *CPU9P) GetXattr(name string) ([]byte, error) {
+	if !xattrAllowed(name) {
+		return nil, syscall.ENOTSUP
+	}
+	buf := make([]byte, 4096)
+	for {
+		n, err := unix.Lgetxattr(l.path, name, buf)
+		if err == unix.ERANGE {
+			buf = make([]byte, len(buf)*2)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+}
+
+// SetXattr implements the 9P2000.L xattr extension via Lsetxattr.
+func (l *CPU9P) SetXattr(name string, value []byte, flags p9.XattrFlags) error {
+	if !xattrAllowed(name) {
+		return syscall.ENOTSUP
+	}
+	return unix.Lsetxattr(l.path, name, value, int(flags))
+}
+
+// ListXattrs implements the 9P2000.L xattr extension via Llistxattr.
+// Names outside xattrNamespaces are filtered out rather than causing
+// the whole call to fail.
+func (l *CPU9P) ListXattrs() ([]string, error) {
+	buf := make([]byte, 4096)
+	n, err := unix.Llistxattr(l.path, buf)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, s := range splitNUL(buf[:n]) {
+		if s != "" && xattrAllowed(s) {
+			names = append(names, s)
+		}
+	}
+	return names, nil
+}
+
+// RemoveXattr implements the 9P2000.L xattr extension via Lremovexattr.
+func (l *CPU9P) RemoveXattr(name string) error {
+	if !xattrAllowed(name) {
+		return syscall.ENOTSUP
+	}
+	return unix.Lremovexattr(l.path, name)
+}
+
+func splitNUL(b []byte) []string {
+	var out []string
+	start := 0
+	for i, c := range b {
+		if c == 0 {
+			out = append(out, string(b[start:i]))
+			start = i + 1
+		}
+	}
+	return out
 }
@@ -0,0 +1,80 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Host is one destination of a FanOut: a host name to prefix output
+// lines with, and the color (an ANSI SGR parameter, e.g. "32" for
+// green) to render that prefix in when the fan-out's stdout is a tty.
+// Color is ignored otherwise.
+type Host struct {
+	Name  string
+	Color string
+	Run   func(stdout, stderr io.Writer) error
+}
+
+// FanOut runs every host's Run concurrently, merging their output into
+// out/errOut with a per-host prefix auto-aligned to the longest host
+// name and colored per Host.Color when tty reports out is a terminal.
+// It returns once every host has finished, aggregating a non-nil error
+// (via multierror) naming every host that failed.
+func FanOut(hosts []Host, out, errOut io.Writer, tty bool) error {
+	width := 0
+	for _, h := range hosts {
+		if len(h.Name) > width {
+			width = len(h.Name)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var mErr error
+	var mErrMu sync.Mutex
+
+	for _, h := range hosts {
+		h := h
+		prefix := fmt.Sprintf("%-*s| ", width, h.Name)
+		if tty && h.Color != "" {
+			prefix = "\x1b[" + h.Color + "m" + prefix + "\x1b[0m"
+		}
+		stdout := NewLinePrefixer(out, prefix, &mu)
+		stderr := NewLinePrefixer(errOut, prefix, &mu)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer stdout.Close()
+			defer stderr.Close()
+			if err := h.Run(stdout, stderr); err != nil {
+				mErrMu.Lock()
+				mErr = multierror.Append(mErr, fmt.Errorf("%s: %v", h.Name, err))
+				mErrMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return mErr
+}
+
+// IsTTY reports whether f looks like a terminal, for callers deciding
+// whether to pass tty=true to FanOut. It is deliberately simple (a
+// CharDevice check) rather than pulling in a terminal-capability
+// library, matching the level of polish cpu applies elsewhere to
+// terminal detection.
+func IsTTY(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,63 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/term"
+)
+
+// LoadSigner reads an SSH private key from path and returns it as an
+// ssh.Signer, prompting for a passphrase on the controlling terminal if
+// the key turns out to be password-protected. gliderlabs/ssh (the
+// server side) can't unpack those itself, so this is strictly a client
+// (dialer)-side concern: once decrypted here, the signer just hands the
+// server ordinary public-key auth.
+func LoadSigner(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:read key %s: %v", path, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	if _, ok := err.(*ssh.PassphraseMissingError); !ok {
+		return signer, err
+	}
+
+	fmt.Fprintf(os.Stderr, "Enter passphrase for %s: ", path)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:read passphrase: %v", err)
+	}
+	return ssh.ParsePrivateKeyWithPassphrase(data, passphrase)
+}
+
+// ForwardAgent requests agent forwarding on session and relays it to
+// whatever agent SSH_AUTH_SOCK points at locally, so commands the
+// remote cpud runs (nested ssh, git, another cpu hop, ...) can reach
+// keys held by the local machine instead of needing their own copies.
+// It is a no-op, not an error, if SSH_AUTH_SOCK isn't set.
+func ForwardAgent(client *ssh.Client, session *ssh.Session) error {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return fmt.Errorf("CPUD:dial SSH_AUTH_SOCK %s: %v", sock, err)
+	}
+
+	ag := agent.NewClient(conn)
+	if err := agent.ForwardToAgent(client, ag); err != nil {
+		return fmt.Errorf("CPUD:ForwardToAgent: %v", err)
+	}
+	return agent.RequestAgentForwarding(session)
+}
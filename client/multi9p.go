@@ -0,0 +1,189 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"os"
+
+	"github.com/hugelgupf/p9/p9"
+)
+
+// MultiAttacher unions several p9.Attacher roots under one tree: a
+// path is resolved by trying each child, in the order given to
+// NewMultiAttacher, and the first to have it wins -- the same
+// precedence rule a PATH environment variable uses. Readdir instead
+// merges every child's listing of a directory, so a caller sees the
+// union of what each backend contributes, with an earlier child's
+// entry shadowing a later child's entry of the same name. This lets
+// cpud mount, say, a base squashfs image and an in-memory cpio
+// overlay as a single root without unpacking either to disk.
+type MultiAttacher struct {
+	attachers []p9.Attacher
+}
+
+// NewMultiAttacher returns a MultiAttacher over attachers, highest
+// precedence first.
+func NewMultiAttacher(attachers ...p9.Attacher) *MultiAttacher {
+	return &MultiAttacher{attachers: attachers}
+}
+
+// Attach implements p9.Attacher.Attach.
+func (m *MultiAttacher) Attach() (p9.File, error) {
+	roots := make([]p9.File, len(m.attachers))
+	for i, a := range m.attachers {
+		r, err := a.Attach()
+		if err != nil {
+			return nil, err
+		}
+		roots[i] = r
+	}
+	return &multiFID{roots: roots}, nil
+}
+
+var _ p9.Attacher = &MultiAttacher{}
+
+// multiFID is a p9.File over one path in each child that still has
+// it; primary indexes the highest-precedence surviving child, the one
+// every single-file operation delegates to.
+type multiFID struct {
+	p9.DefaultWalkGetAttr
+
+	roots   []p9.File
+	primary int
+}
+
+var _ p9.File = &multiFID{}
+
+func (l *multiFID) file() p9.File { return l.roots[l.primary] }
+
+// Walk implements p9.File.Walk.
+func (l *multiFID) Walk(names []string) ([]p9.QID, p9.File, error) {
+	next := make([]p9.File, len(l.roots))
+	var qids []p9.QID
+	primary := -1
+	for i, r := range l.roots {
+		if r == nil {
+			continue
+		}
+		q, f, err := r.Walk(names)
+		if err != nil {
+			continue
+		}
+		next[i] = f
+		if primary == -1 {
+			primary = i
+			qids = q
+		}
+	}
+	if primary == -1 {
+		return nil, nil, os.ErrNotExist
+	}
+	return qids, &multiFID{roots: next, primary: primary}, nil
+}
+
+// FSync implements p9.File.FSync.
+func (l *multiFID) FSync() error { return l.file().FSync() }
+
+// Close implements p9.File.Close.
+func (l *multiFID) Close() error { return l.file().Close() }
+
+// Open implements p9.File.Open.
+func (l *multiFID) Open(mode p9.OpenFlags) (p9.QID, uint32, error) { return l.file().Open(mode) }
+
+// ReadAt implements p9.File.ReadAt.
+func (l *multiFID) ReadAt(p []byte, offset int64) (int, error) { return l.file().ReadAt(p, offset) }
+
+// WriteAt implements p9.File.WriteAt.
+func (l *multiFID) WriteAt(p []byte, offset int64) (int, error) {
+	return l.file().WriteAt(p, offset)
+}
+
+// Create implements p9.File.Create.
+func (l *multiFID) Create(name string, mode p9.OpenFlags, permissions p9.FileMode, uid p9.UID, gid p9.GID) (p9.File, p9.QID, uint32, error) {
+	return l.file().Create(name, mode, permissions, uid, gid)
+}
+
+// Mkdir implements p9.File.Mkdir.
+func (l *multiFID) Mkdir(name string, permissions p9.FileMode, uid p9.UID, gid p9.GID) (p9.QID, error) {
+	return l.file().Mkdir(name, permissions, uid, gid)
+}
+
+// Symlink implements p9.File.Symlink.
+func (l *multiFID) Symlink(oldname, newname string, uid p9.UID, gid p9.GID) (p9.QID, error) {
+	return l.file().Symlink(oldname, newname, uid, gid)
+}
+
+// Link implements p9.File.Link.
+func (l *multiFID) Link(target p9.File, newname string) error {
+	return l.file().Link(target, newname)
+}
+
+// UnlinkAt implements p9.File.UnlinkAt.
+func (l *multiFID) UnlinkAt(name string, flags uint32) error {
+	return l.file().UnlinkAt(name, flags)
+}
+
+// SetAttr implements p9.File.SetAttr.
+func (l *multiFID) SetAttr(mask p9.SetAttrMask, attr p9.SetAttr) error {
+	return l.file().SetAttr(mask, attr)
+}
+
+// Readdir implements p9.File.Readdir. It merges every surviving
+// child's listing of this directory, keeping the first (highest
+// precedence) entry it sees for any given name.
+func (l *multiFID) Readdir(offset uint64, count uint32) (p9.Dirents, error) {
+	seen := map[string]bool{}
+	var merged p9.Dirents
+	for _, r := range l.roots {
+		if r == nil {
+			continue
+		}
+		dirents, err := r.Readdir(0, count)
+		if err != nil {
+			continue
+		}
+		for _, d := range dirents {
+			if seen[d.Name] {
+				continue
+			}
+			seen[d.Name] = true
+			d.Offset = uint64(len(merged))
+			merged = append(merged, d)
+		}
+	}
+	return merged, nil
+}
+
+// Readlink implements p9.File.Readlink.
+func (l *multiFID) Readlink() (string, error) { return l.file().Readlink() }
+
+// Flush implements p9.File.Flush.
+func (l *multiFID) Flush() error { return l.file().Flush() }
+
+// Renamed implements p9.File.Renamed.
+func (l *multiFID) Renamed(parent p9.File, newName string) { l.file().Renamed(parent, newName) }
+
+// Mknod implements p9.File.Mknod.
+func (l *multiFID) Mknod(name string, mode p9.FileMode, major uint32, minor uint32, uid p9.UID, gid p9.GID) (p9.QID, error) {
+	return l.file().Mknod(name, mode, major, minor, uid, gid)
+}
+
+// Rename implements p9.File.Rename.
+func (l *multiFID) Rename(directory p9.File, name string) error {
+	return l.file().Rename(directory, name)
+}
+
+// RenameAt implements p9.File.RenameAt.
+func (l *multiFID) RenameAt(oldName string, newDir p9.File, newName string) error {
+	return l.file().RenameAt(oldName, newDir, newName)
+}
+
+// StatFS implements p9.File.StatFS.
+func (l *multiFID) StatFS() (p9.FSStat, error) { return l.file().StatFS() }
+
+// GetAttr implements p9.File.GetAttr.
+func (l *multiFID) GetAttr(req p9.AttrMask) (p9.QID, p9.AttrMask, p9.Attr, error) {
+	return l.file().GetAttr(req)
+}
@@ -0,0 +1,172 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// wh and whOpaque are the OCI image-spec's whiteout conventions: a
+// regular file ".wh.foo" in a layer hides "foo" from every layer
+// below it, and ".wh..wh.opq" inside a directory hides everything
+// below it that isn't also present in this layer or above.
+const (
+	wh       = ".wh."
+	whOpaque = ".wh..wh.opq"
+)
+
+// NewOCILayers9P composes an ordered stack of gzip-or-plain tar layers
+// -- bottom layer first, the order OCI image manifests list them in
+// -- into a single read-only p9.Attacher, applying whiteouts the same
+// way a real overlay mount would: each layer's records are merged
+// into an accumulated name->record map in order, a ".wh.X" entry
+// deletes "X" from everything accumulated so far instead of being
+// served itself, and a ".wh..wh.opq" entry first drops every path
+// under its directory that the layers below put there.
+func NewOCILayers9P(layers []string) (*recordFS, error) {
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("%w: no layers", os.ErrInvalid)
+	}
+
+	m := map[string]record{"/": {info: recordInfo{Name: "/", Mode: uint64(syscall.S_IFDIR) | 0o755, NLink: 2}}}
+	for _, path := range layers {
+		recs, err := readTarLayer(path)
+		if err != nil {
+			return nil, fmt.Errorf("layer %s: %w", path, err)
+		}
+		// inLayer holds the names this layer has added so far; an
+		// opaque whiteout later in the same layer leaves them alone.
+		inLayer := map[string]bool{}
+		for _, r := range recs {
+			base := filepath.Base(r.info.Name)
+			dir := filepath.Dir(r.info.Name)
+
+			if base == whOpaque {
+				for name := range m {
+					if name == dir || inLayer[name] {
+						continue
+					}
+					if rel, err := filepath.Rel(dir, name); err == nil && !strings.HasPrefix(rel, "..") {
+						delete(m, name)
+					}
+				}
+				continue
+			}
+			if strings.HasPrefix(base, wh) {
+				hidden := filepath.Join(dir, strings.TrimPrefix(base, wh))
+				delete(m, hidden)
+				for name := range m {
+					if rel, err := filepath.Rel(hidden, name); err == nil && !strings.HasPrefix(rel, "..") {
+						delete(m, name)
+					}
+				}
+				continue
+			}
+			m[r.info.Name] = r
+			inLayer[r.info.Name] = true
+		}
+	}
+
+	// The root goes first: recordFS.Attach serves recs[0] as "/".
+	recs := make([]record, 0, len(m))
+	recs = append(recs, m["/"])
+	for name, r := range m {
+		if name == "/" {
+			continue
+		}
+		recs = append(recs, r)
+	}
+	return newRecordFS(recs), nil
+}
+
+// readTarLayer reads a single OCI layer -- a tar stream, optionally
+// gzip-compressed regardless of file extension -- fully into memory
+// and returns its records. OCI layers are typically only a few
+// hundred MiB and, unlike NewTAR9P's standalone archive, are re-merged
+// by every layer above them, so buffering content up front (rather
+// than seeking the compressed stream per read, which gzip does not
+// support) keeps the merge logic simple.
+func readTarLayer(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return readTarRecords(r)
+}
+
+// readTarRecords decodes a tar stream that is not necessarily seekable
+// (a gzip.Reader, notably) into records with their content buffered
+// in memory, the non-lazy counterpart to NewTAR9P's file-backed
+// ReadAt.
+func readTarRecords(r io.Reader) ([]record, error) {
+	tr := tar.NewReader(r)
+	var recs []record
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Clean("/"+hdr.Name), "/")
+		if name == "" {
+			name = "/"
+		}
+
+		rec := record{info: recordInfo{
+			Name:  name,
+			Mode:  tarMode(hdr),
+			UID:   uint64(hdr.Uid),
+			GID:   uint64(hdr.Gid),
+			NLink: 1,
+			Size:  uint64(hdr.Size),
+			MTime: uint64(hdr.ModTime.Unix()),
+		}}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			rec.info.NLink = 2
+		case tar.TypeSymlink, tar.TypeLink:
+			rec.link = hdr.Linkname
+		case tar.TypeReg, tar.TypeRegA:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			rec.readAt = func(p []byte, offset int64) (int, error) {
+				if offset < 0 || offset > int64(len(data)) {
+					return 0, os.ErrInvalid
+				}
+				n := copy(p, data[offset:])
+				if n < len(p) {
+					return n, io.EOF
+				}
+				return n, nil
+			}
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// LinePrefixer is an io.WriteCloser that splits whatever is written to it
+// on '\n' and writes each complete line to an underlying writer with a
+// fixed prefix, e.g. "h1| " in front of every line of host h1's output.
+// Several LinePrefixers can share one mu so that lines from different
+// hosts, written concurrently, never interleave mid-line in the shared
+// terminal; pass the same *sync.Mutex to each one a fan-out driver
+// creates.
+//
+// A partial line (no trailing '\n' yet) is buffered until either more
+// data completes it or Close is called, at which point whatever is left
+// is flushed with the prefix as if it had ended in '\n'.
+type LinePrefixer struct {
+	w      io.Writer
+	prefix string
+	mu     *sync.Mutex
+	buf    bytes.Buffer
+}
+
+// NewLinePrefixer returns a LinePrefixer writing to w, prefixing every
+// line with prefix. mu is shared across all prefixers writing to the
+// same underlying w; callers that only have one prefixer may pass a
+// fresh &sync.Mutex{}.
+func NewLinePrefixer(w io.Writer, prefix string, mu *sync.Mutex) *LinePrefixer {
+	return &LinePrefixer{w: w, prefix: prefix, mu: mu}
+}
+
+// Write implements io.Writer.
+func (p *LinePrefixer) Write(b []byte) (int, error) {
+	n := len(b)
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			p.buf.Write(b)
+			break
+		}
+		p.buf.Write(b[:i])
+		if err := p.flushLine(); err != nil {
+			return n, err
+		}
+		b = b[i+1:]
+	}
+	return n, nil
+}
+
+// Close flushes any trailing partial line (one with no '\n' yet) and
+// implements io.Closer.
+func (p *LinePrefixer) Close() error {
+	if p.buf.Len() == 0 {
+		return nil
+	}
+	return p.flushLine()
+}
+
+// flushLine writes p.buf out as one prefixed line and resets it.
+func (p *LinePrefixer) flushLine() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	defer p.buf.Reset()
+	if _, err := io.WriteString(p.w, p.prefix); err != nil {
+		return err
+	}
+	if _, err := p.buf.WriteTo(p.w); err != nil {
+		return err
+	}
+	_, err := io.WriteString(p.w, "\n")
+	return err
+}
@@ -0,0 +1,40 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestLinePrefixer(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewLinePrefixer(&buf, "h1| ", &sync.Mutex{})
+
+	if _, err := p.Write([]byte("one\ntwo\nthre")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got, want := buf.String(), "h1| one\nh1| two\n"; got != want {
+		t.Errorf("after partial write: got %q, want %q", got, want)
+	}
+
+	if _, err := p.Write([]byte("e\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got, want := buf.String(), "h1| one\nh1| two\nh1| three\n"; got != want {
+		t.Errorf("after completing line: got %q, want %q", got, want)
+	}
+
+	if _, err := p.Write([]byte("trailing")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got, want := buf.String(), "h1| one\nh1| two\nh1| three\nh1| trailing\n"; got != want {
+		t.Errorf("after Close: got %q, want %q", got, want)
+	}
+}
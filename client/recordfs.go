@@ -0,0 +1,315 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hugelgupf/p9/p9"
+)
+
+// recordInfo is the attribute set recordFS needs for one entry,
+// independent of which archive format produced it. Mode carries the
+// POSIX type bits (S_IFREG/S_IFDIR/S_IFLNK/...) in the same way
+// cpio.Info.Mode already does, so GetAttr can hand it straight to
+// p9.FileMode.
+type recordInfo struct {
+	Name  string
+	Mode  uint64
+	UID   uint64
+	GID   uint64
+	NLink uint64
+	Size  uint64
+	MTime uint64
+}
+
+// record is one entry in a recordFS: its attributes, an optional
+// ReadAt for regular file content (nil for directories and most
+// special files), and an optional symlink target.
+type record struct {
+	info   recordInfo
+	readAt func(p []byte, offset int64) (int, error)
+	link   string
+}
+
+// recordFS is the read-only, in-memory directory tree shared by the
+// tar, zip, squashfs, and OCI-layer Attachers: each builds a []record
+// however suits its source format -- streaming a tar, indexing a zip
+// central directory, walking a squashfs inode tree, merging OCI
+// layers -- and gets Walk/Readdir/GetAttr/ReadAt for free, the same
+// way CPIO9P hand-rolled them for cpio specifically.
+type recordFS struct {
+	p9.DefaultWalkGetAttr
+
+	recs []record
+	m    map[string]uint64
+}
+
+// newRecordFS indexes recs by name and returns a recordFS ready to
+// Attach. recs[0] must be the root directory "/", since Attach serves
+// it as the root; every other entry may appear anywhere, but callers
+// that care about Readdir completeness must include every ancestor
+// directory explicitly, same as cpio archives do.
+func newRecordFS(recs []record) *recordFS {
+	m := make(map[string]uint64, len(recs))
+	for i, r := range recs {
+		m[r.info.Name] = uint64(i)
+	}
+	return &recordFS{recs: recs, m: m}
+}
+
+// Attach implements p9.Attacher.Attach. Only works for root.
+func (s *recordFS) Attach() (p9.File, error) {
+	return &recordFID{fs: s, path: 0}, nil
+}
+
+var _ p9.Attacher = &recordFS{}
+
+// recordFID is a read-only FID over a recordFS.
+type recordFID struct {
+	p9.DefaultWalkGetAttr
+
+	fs   *recordFS
+	path uint64
+}
+
+var _ p9.File = &recordFID{}
+
+func (l *recordFID) rec() (*record, error) {
+	if l.path >= uint64(len(l.fs.recs)) {
+		return nil, os.ErrNotExist
+	}
+	return &l.fs.recs[l.path], nil
+}
+
+func (l *recordFID) info() (p9.QID, error) {
+	if _, err := l.rec(); err != nil {
+		return p9.QID{}, err
+	}
+	return p9.QID{Path: l.path}, nil
+}
+
+// Walk implements p9.File.Walk.
+func (l *recordFID) Walk(names []string) ([]p9.QID, p9.File, error) {
+	r, err := l.rec()
+	if err != nil {
+		return nil, nil, err
+	}
+	last := &recordFID{path: l.path, fs: l.fs}
+	if len(names) == 0 {
+		qid, err := last.info()
+		if err != nil {
+			return nil, nil, err
+		}
+		return []p9.QID{qid}, last, nil
+	}
+
+	var qids []p9.QID
+	fullpath := r.info.Name
+	for _, name := range names {
+		fullpath = filepath.Join(fullpath, name)
+		ix, ok := l.fs.m[fullpath]
+		if !ok {
+			return nil, nil, os.ErrNotExist
+		}
+		last.path = ix
+		qid, err := last.info()
+		if err != nil {
+			return nil, nil, err
+		}
+		qids = append(qids, qid)
+	}
+	return qids, last, nil
+}
+
+// FSync implements p9.File.FSync.
+func (l *recordFID) FSync() error { return nil }
+
+// Close implements p9.File.Close.
+func (l *recordFID) Close() error { return nil }
+
+// Open implements p9.File.Open.
+func (l *recordFID) Open(mode p9.OpenFlags) (p9.QID, uint32, error) {
+	qid, err := l.info()
+	if err != nil {
+		return qid, 0, err
+	}
+	if mode != p9.ReadOnly {
+		return qid, 0, os.ErrPermission
+	}
+	return qid, 0, nil
+}
+
+// ReadAt implements p9.File.ReadAt.
+func (l *recordFID) ReadAt(p []byte, offset int64) (int, error) {
+	r, err := l.rec()
+	if err != nil {
+		return -1, err
+	}
+	if r.readAt == nil {
+		return -1, os.ErrInvalid
+	}
+	return r.readAt(p, offset)
+}
+
+// WriteAt implements p9.File.WriteAt. recordFS is read-only.
+func (l *recordFID) WriteAt(p []byte, offset int64) (int, error) {
+	return -1, os.ErrPermission
+}
+
+// Create implements p9.File.Create. recordFS is read-only.
+func (l *recordFID) Create(name string, mode p9.OpenFlags, permissions p9.FileMode, _ p9.UID, _ p9.GID) (p9.File, p9.QID, uint32, error) {
+	return nil, p9.QID{}, 0, os.ErrPermission
+}
+
+// Mkdir implements p9.File.Mkdir. recordFS is read-only.
+func (l *recordFID) Mkdir(name string, permissions p9.FileMode, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	return p9.QID{}, os.ErrPermission
+}
+
+// Symlink implements p9.File.Symlink. recordFS is read-only.
+func (l *recordFID) Symlink(oldname, newname string, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	return p9.QID{}, os.ErrPermission
+}
+
+// Link implements p9.File.Link. recordFS is read-only.
+func (l *recordFID) Link(target p9.File, newname string) error {
+	return os.ErrPermission
+}
+
+// UnlinkAt implements p9.File.UnlinkAt. recordFS is read-only.
+func (l *recordFID) UnlinkAt(name string, flags uint32) error {
+	return os.ErrPermission
+}
+
+// SetAttr implements p9.File.SetAttr. recordFS is read-only.
+func (l *recordFID) SetAttr(mask p9.SetAttrMask, attr p9.SetAttr) error {
+	return os.ErrPermission
+}
+
+func (l *recordFID) readdir() ([]uint64, error) {
+	r, err := l.rec()
+	if err != nil {
+		return nil, err
+	}
+	dn := r.info.Name
+	var list []uint64
+	for name, idx := range l.fs.m {
+		if name == dn {
+			continue
+		}
+		b, err := filepath.Rel(dn, name)
+		if err != nil {
+			continue
+		}
+		if dir, _ := filepath.Split(b); len(dir) > 0 {
+			continue
+		}
+		list = append(list, idx)
+	}
+	return list, nil
+}
+
+// Readdir implements p9.File.Readdir.
+func (l *recordFID) Readdir(offset uint64, count uint32) (p9.Dirents, error) {
+	qid, err := l.info()
+	if err != nil {
+		return nil, err
+	}
+	list, err := l.readdir()
+	if err != nil {
+		return nil, err
+	}
+	dirents := p9.Dirents{{QID: qid, Type: qid.Type, Name: ".", Offset: l.path}}
+	for _, i := range list {
+		entry := recordFID{path: i, fs: l.fs}
+		eqid, err := entry.info()
+		if err != nil {
+			continue
+		}
+		r, err := entry.rec()
+		if err != nil {
+			continue
+		}
+		dirents = append(dirents, p9.Dirent{
+			QID:    eqid,
+			Type:   eqid.Type,
+			Name:   r.info.Name,
+			Offset: i,
+		})
+	}
+	return dirents, nil
+}
+
+// Readlink implements p9.File.Readlink.
+func (l *recordFID) Readlink() (string, error) {
+	r, err := l.rec()
+	if err != nil {
+		return "", err
+	}
+	if r.link == "" {
+		return "", os.ErrPermission
+	}
+	return r.link, nil
+}
+
+// Flush implements p9.File.Flush.
+func (l *recordFID) Flush() error { return nil }
+
+// Renamed implements p9.File.Renamed.
+func (l *recordFID) Renamed(parent p9.File, newName string) {}
+
+// Mknod implements p9.File.Mknod. recordFS is read-only.
+func (l *recordFID) Mknod(name string, mode p9.FileMode, major uint32, minor uint32, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	return p9.QID{}, os.ErrPermission
+}
+
+// Rename implements p9.File.Rename. recordFS is read-only.
+func (l *recordFID) Rename(directory p9.File, name string) error {
+	return os.ErrPermission
+}
+
+// RenameAt implements p9.File.RenameAt. recordFS is read-only.
+func (l *recordFID) RenameAt(oldName string, newDir p9.File, newName string) error {
+	return os.ErrPermission
+}
+
+// StatFS implements p9.File.StatFS.
+//
+// Not implemented.
+func (l *recordFID) StatFS() (p9.FSStat, error) {
+	return p9.FSStat{}, os.ErrInvalid
+}
+
+// GetAttr implements p9.File.GetAttr.
+func (l *recordFID) GetAttr(req p9.AttrMask) (p9.QID, p9.AttrMask, p9.Attr, error) {
+	qid, err := l.info()
+	if err != nil {
+		return qid, p9.AttrMask{}, p9.Attr{}, err
+	}
+	r, _ := l.rec()
+
+	attr := p9.Attr{
+		Mode:         p9.FileMode(r.info.Mode),
+		UID:          p9.UID(r.info.UID),
+		GID:          p9.GID(r.info.GID),
+		NLink:        p9.NLink(r.info.NLink),
+		Size:         r.info.Size,
+		BlockSize:    4096,
+		Blocks:       r.info.Size / 4096,
+		MTimeSeconds: r.info.MTime,
+	}
+	valid := p9.AttrMask{
+		Mode:   true,
+		UID:    true,
+		GID:    true,
+		NLink:  true,
+		Size:   true,
+		Blocks: true,
+		MTime:  true,
+	}
+	return qid, valid, attr, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hugelgupf/p9/p9"
+	"github.com/jacobsa/fuse"
+	"github.com/u-root/cpu/session"
+)
+
+// MountReverse9P is the client-side half of cpud's reverse export
+// (server.Session.reverseExport): it takes the already-forwarded
+// connection cpud dialed back in over (the same ssh -R "tcpip-forward"
+// channel Namespace's forward mount uses, just in the other direction)
+// and FUSE-mounts the 9p root it serves at mountTarget, so that files
+// under the remote cpu's CPU_REVERSE_ROOT show up locally.
+//
+// It reuses session.NewP9FS, the same cacheable 9p-over-FUSE gateway
+// cpud's own Namespace can use for its forward mount, rather than
+// re-implementing a second p9-to-FUSE bridge.
+//
+// Establishing the -R forward itself, and dialing MountReverse9P at
+// the right moment, is a client binary's job; this tree has no
+// cmds/cpu to wire that into yet, so this function is exported for
+// that future caller but currently unused.
+func MountReverse9P(conn io.ReadWriteCloser, mountTarget string, msize int) (*fuse.MountedFileSystem, error) {
+	cl, err := p9.NewClient(conn, p9.WithMessageSize(uint32(msize)))
+	if err != nil {
+		return nil, fmt.Errorf("reverse 9p client: %w", err)
+	}
+	root, err := cl.Attach("")
+	if err != nil {
+		return nil, fmt.Errorf("reverse 9p attach: %w", err)
+	}
+
+	fs, cfs, err := session.NewP9FS(cl, 5*time.Second, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("reverse 9p FUSE gateway: %w", err)
+	}
+	cfs.SetRoot(root)
+
+	c := &fuse.MountConfig{
+		FSName:  "cpu-reverse",
+		Options: map[string]string{"subtype": "cpu9p-reverse"},
+	}
+	return fuse.Mount(mountTarget, fs, c)
+}
@@ -0,0 +1,627 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// NewSquashFS9P returns a read-only p9.Attacher over the squashfs
+// image at path c. It understands enough of the on-disk format --
+// superblock, the zlib-compressed metadata streams that hold the
+// inode and directory tables, the id table, and basic (non-extended)
+// inodes -- to walk the whole tree once at open time and build the
+// same flat []record that NewTAR9P and NewZip9P do. It deliberately
+// does not support extended inodes, xattrs, or fragment blocks: a
+// file whose last, partial block was packed into a fragment (as
+// mksquashfs does by default for small files) reads back short rather
+// than guessing at a fragment-table layout this package cannot
+// verify. Images built with -no-fragments, or where every file is a
+// whole number of blocks, are unaffected.
+func NewSquashFS9P(c string) (*recordFS, error) {
+	f, err := os.Open(c)
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := readSquashfsSuper(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if sb.Compression != sqCompZlib {
+		f.Close()
+		return nil, fmt.Errorf("%s: unsupported squashfs compression id %d (only zlib is supported)", c, sb.Compression)
+	}
+
+	sq := &squashfsReader{
+		f:          f,
+		sb:         sb,
+		inodeCache: newMetaCache(f),
+		dirCache:   newMetaCache(f),
+		dataCache:  map[int64][]byte{},
+	}
+	if sq.ids, err = sq.readIDTable(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	rootBlock, rootOffset := unpackInodeRef(sb.RootInode)
+	root, err := sq.readInode(rootBlock, rootOffset)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if root.kind != sqInodeBasicDir {
+		f.Close()
+		return nil, fmt.Errorf("%s: root inode is not a directory", c)
+	}
+
+	recs := []record{{info: recordInfo{Name: "/", Mode: root.mode, UID: root.uid, GID: root.gid, NLink: 2, MTime: root.mtime}}}
+	if recs, err = sq.walk("/", root, recs); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return newRecordFS(recs), nil
+}
+
+const (
+	sqMagic     = 0x73717368
+	sqCompZlib  = 1
+	noFragIndex = 0xffffffff
+)
+
+const (
+	sqInodeBasicDir = iota + 1
+	sqInodeBasicFile
+	sqInodeBasicSymlink
+	sqInodeBasicBlockDev
+	sqInodeBasicCharDev
+	sqInodeBasicFifo
+	sqInodeBasicSocket
+)
+
+// squashfsSuper is the 96-byte squashfs 4.0 superblock.
+type squashfsSuper struct {
+	Magic              uint32
+	Inodes             uint32
+	MkfsTime           uint32
+	BlockSize          uint32
+	Fragments          uint32
+	Compression        uint16
+	BlockLog           uint16
+	Flags              uint16
+	NoIDs              uint16
+	Major              uint16
+	Minor              uint16
+	RootInode          uint64
+	BytesUsed          uint64
+	IDTableStart       uint64
+	XattrIDTableStart  uint64
+	InodeTableStart    uint64
+	DirectoryTableStart uint64
+	FragmentTableStart uint64
+	LookupTableStart   uint64
+}
+
+func readSquashfsSuper(f *os.File) (squashfsSuper, error) {
+	var sb squashfsSuper
+	buf := make([]byte, 96)
+	if _, err := f.ReadAt(buf, 0); err != nil {
+		return sb, err
+	}
+	if err := binary.Read(bytes.NewReader(buf), binary.LittleEndian, &sb); err != nil {
+		return sb, err
+	}
+	if sb.Magic != sqMagic {
+		return sb, fmt.Errorf("not a squashfs image (bad magic)")
+	}
+	return sb, nil
+}
+
+// unpackInodeRef splits a 64-bit inode reference into the inode
+// table block offset (relative to InodeTableStart) and the
+// in-block byte offset, the encoding squashfs uses for
+// squashfs_super_block.root_inode and every directory entry.
+func unpackInodeRef(ref uint64) (block uint32, offset uint16) {
+	return uint32(ref >> 16), uint16(ref)
+}
+
+// metaCache decompresses and caches the 8KiB-max metadata blocks that
+// make up the inode, directory, and id tables; each block is prefixed
+// by a 2-byte header whose top bit says whether it is stored raw.
+type metaCache struct {
+	f     *os.File
+	cache map[int64][]byte
+}
+
+func newMetaCache(f *os.File) *metaCache {
+	return &metaCache{f: f, cache: map[int64][]byte{}}
+}
+
+// block returns the decompressed bytes of the metadata block whose
+// header starts at off, along with the file offset of the block that
+// follows it.
+func (c *metaCache) block(off int64) ([]byte, int64, error) {
+	if data, ok := c.cache[off]; ok {
+		var hdr [2]byte
+		if _, err := c.f.ReadAt(hdr[:], off); err != nil {
+			return nil, 0, err
+		}
+		length := int64(binary.LittleEndian.Uint16(hdr[:]) &^ 0x8000)
+		return data, off + 2 + length, nil
+	}
+
+	var hdr [2]byte
+	if _, err := c.f.ReadAt(hdr[:], off); err != nil {
+		return nil, 0, err
+	}
+	h := binary.LittleEndian.Uint16(hdr[:])
+	length := int64(h &^ 0x8000)
+	raw := h&0x8000 != 0
+
+	buf := make([]byte, length)
+	if _, err := c.f.ReadAt(buf, off+2); err != nil {
+		return nil, 0, err
+	}
+	var data []byte
+	if raw {
+		data = buf
+	} else {
+		zr, err := zlib.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, 0, err
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, 0, err
+		}
+	}
+	c.cache[off] = data
+	return data, off + 2 + length, nil
+}
+
+// metaReader reads sequentially through a metadata stream starting at
+// a given (block, offset) inode/directory reference, crossing block
+// boundaries transparently.
+type metaReader struct {
+	c   *metaCache
+	off int64
+	buf []byte
+	pos int
+}
+
+func newMetaReader(c *metaCache, start int64, offset uint16) (*metaReader, error) {
+	mr := &metaReader{c: c, off: start}
+	if err := mr.fill(); err != nil {
+		return nil, err
+	}
+	rem := int(offset)
+	for rem >= len(mr.buf) {
+		rem -= len(mr.buf)
+		if err := mr.fill(); err != nil {
+			return nil, err
+		}
+	}
+	mr.pos = rem
+	return mr, nil
+}
+
+func (mr *metaReader) fill() error {
+	buf, next, err := mr.c.block(mr.off)
+	if err != nil {
+		return err
+	}
+	mr.buf = buf
+	mr.off = next
+	mr.pos = 0
+	return nil
+}
+
+func (mr *metaReader) read(p []byte) error {
+	n := 0
+	for n < len(p) {
+		if mr.pos >= len(mr.buf) {
+			if err := mr.fill(); err != nil {
+				return err
+			}
+			continue
+		}
+		c := copy(p[n:], mr.buf[mr.pos:])
+		n += c
+		mr.pos += c
+	}
+	return nil
+}
+
+func (mr *metaReader) u16() (uint16, error) {
+	var b [2]byte
+	if err := mr.read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b[:]), nil
+}
+
+func (mr *metaReader) u32() (uint32, error) {
+	var b [4]byte
+	if err := mr.read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func (mr *metaReader) bytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if err := mr.read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// sqInode is a parsed squashfs inode, basic types only.
+type sqInode struct {
+	kind  uint16
+	mode  uint64
+	uid   uint64
+	gid   uint64
+	mtime uint64
+
+	dirBlock  uint32
+	dirOffset uint16
+	dirSize   uint32
+
+	blocksStart uint64
+	fragIndex   uint32
+	fragOffset  uint32
+	fileSize    uint64
+	blockSizes  []uint32
+
+	target string
+}
+
+type squashfsReader struct {
+	f          *os.File
+	sb         squashfsSuper
+	inodeCache *metaCache
+	dirCache   *metaCache
+	ids        []uint32
+
+	dataCache map[int64][]byte
+}
+
+// readIDTable loads the uid/gid table: an index array of NoIDs/2048
+// rounded-up metadata-block pointers stored raw at IDTableStart, each
+// pointing at a metadata block of up to 2048 little-endian uint32 ids.
+func (sq *squashfsReader) readIDTable() ([]uint32, error) {
+	n := int(sq.sb.NoIDs)
+	if n == 0 {
+		return nil, nil
+	}
+	blocks := (n + 2047) / 2048
+	ptrs := make([]uint64, blocks)
+	buf := make([]byte, 8*blocks)
+	if _, err := sq.f.ReadAt(buf, int64(sq.sb.IDTableStart)); err != nil {
+		return nil, err
+	}
+	for i := range ptrs {
+		ptrs[i] = binary.LittleEndian.Uint64(buf[i*8:])
+	}
+
+	ids := make([]uint32, 0, n)
+	idCache := newMetaCache(sq.f)
+	for _, p := range ptrs {
+		data, _, err := idCache.block(int64(p))
+		if err != nil {
+			return nil, err
+		}
+		for off := 0; off+4 <= len(data) && len(ids) < n; off += 4 {
+			ids = append(ids, binary.LittleEndian.Uint32(data[off:]))
+		}
+	}
+	return ids, nil
+}
+
+func (sq *squashfsReader) idOrZero(idx uint16) uint64 {
+	if int(idx) >= len(sq.ids) {
+		return 0
+	}
+	return uint64(sq.ids[idx])
+}
+
+// readInode parses the basic inode at (block, offset) in the inode
+// table.
+func (sq *squashfsReader) readInode(block uint32, offset uint16) (*sqInode, error) {
+	mr, err := newMetaReader(sq.inodeCache, int64(sq.sb.InodeTableStart)+int64(block), offset)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := mr.u16()
+	if err != nil {
+		return nil, err
+	}
+	perm, err := mr.u16()
+	if err != nil {
+		return nil, err
+	}
+	uidIdx, err := mr.u16()
+	if err != nil {
+		return nil, err
+	}
+	gidIdx, err := mr.u16()
+	if err != nil {
+		return nil, err
+	}
+	mtime, err := mr.u32()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mr.u32(); err != nil { // inode number; unused
+		return nil, err
+	}
+
+	in := &sqInode{kind: kind, uid: sq.idOrZero(uidIdx), gid: sq.idOrZero(gidIdx), mtime: uint64(mtime)}
+	switch kind {
+	case sqInodeBasicDir:
+		startBlock, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := mr.u32(); err != nil { // nlink; unused, recordInfo.NLink is fixed at 2 for dirs
+			return nil, err
+		}
+		size, err := mr.u16()
+		if err != nil {
+			return nil, err
+		}
+		doffset, err := mr.u16()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := mr.u32(); err != nil { // parent inode number; unused
+			return nil, err
+		}
+		in.dirBlock, in.dirOffset, in.dirSize = startBlock, doffset, uint32(size)
+		in.mode = uint64(syscall.S_IFDIR) | uint64(perm&0o7777)
+
+	case sqInodeBasicFile:
+		blocksStart, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		fragIndex, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		fragOffset, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		fsize, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		in.blocksStart, in.fragIndex, in.fragOffset, in.fileSize = uint64(blocksStart), fragIndex, fragOffset, uint64(fsize)
+		nblocks := in.fileSize / uint64(sq.sb.BlockSize)
+		if fragIndex == noFragIndex && in.fileSize%uint64(sq.sb.BlockSize) != 0 {
+			nblocks++
+		}
+		in.blockSizes = make([]uint32, nblocks)
+		for i := range in.blockSizes {
+			if in.blockSizes[i], err = mr.u32(); err != nil {
+				return nil, err
+			}
+		}
+		in.mode = uint64(syscall.S_IFREG) | uint64(perm&0o7777)
+
+	case sqInodeBasicSymlink:
+		if _, err := mr.u32(); err != nil { // nlink; unused
+			return nil, err
+		}
+		tsize, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		target, err := mr.bytes(int(tsize))
+		if err != nil {
+			return nil, err
+		}
+		in.target = string(target)
+		in.mode = uint64(syscall.S_IFLNK) | uint64(perm&0o7777)
+
+	case sqInodeBasicBlockDev, sqInodeBasicCharDev:
+		if _, err := mr.u32(); err != nil { // nlink; unused
+			return nil, err
+		}
+		if _, err := mr.u32(); err != nil { // rdev; not surfaced
+			return nil, err
+		}
+		t := uint64(syscall.S_IFCHR)
+		if kind == sqInodeBasicBlockDev {
+			t = uint64(syscall.S_IFBLK)
+		}
+		in.mode = t | uint64(perm&0o7777)
+
+	case sqInodeBasicFifo:
+		if _, err := mr.u32(); err != nil { // nlink; unused
+			return nil, err
+		}
+		in.mode = uint64(syscall.S_IFIFO) | uint64(perm&0o7777)
+
+	case sqInodeBasicSocket:
+		if _, err := mr.u32(); err != nil { // nlink; unused
+			return nil, err
+		}
+		in.mode = uint64(syscall.S_IFSOCK) | uint64(perm&0o7777)
+
+	default:
+		return nil, fmt.Errorf("squashfs: inode type %d (extended inodes) not supported", kind)
+	}
+	return in, nil
+}
+
+// blockData decompresses (or returns raw) the idx'th data block of a
+// file whose blocks start at archive offset start, caching by archive
+// offset.
+func (sq *squashfsReader) blockData(start int64, sizes []uint32, idx int) ([]byte, error) {
+	off := start
+	for i := 0; i < idx; i++ {
+		off += int64(sizes[i] &^ (1 << 24))
+	}
+	if data, ok := sq.dataCache[off]; ok {
+		return data, nil
+	}
+	sz := sizes[idx]
+	raw := sz&(1<<24) != 0
+	length := int64(sz &^ (1 << 24))
+	if length == 0 {
+		// A sparse block: length 0 means a hole, read as zero fill.
+		data := make([]byte, sq.sb.BlockSize)
+		sq.dataCache[off] = data
+		return data, nil
+	}
+	buf := make([]byte, length)
+	if _, err := sq.f.ReadAt(buf, off); err != nil {
+		return nil, err
+	}
+	var data []byte
+	if raw {
+		data = buf
+	} else {
+		zr, err := zlib.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		var err2 error
+		if data, err2 = io.ReadAll(zr); err2 != nil {
+			return nil, err2
+		}
+	}
+	sq.dataCache[off] = data
+	return data, nil
+}
+
+// readAt implements ReadAt for a basic-file inode's full (non-
+// fragment) blocks. Bytes past the last whole block -- i.e. a tail
+// that mksquashfs packed into a fragment -- are not available and
+// read back as io.EOF, per the NewSquashFS9P doc comment.
+func (in *sqInode) readAt(sq *squashfsReader, p []byte, offset int64) (int, error) {
+	whole := int64(len(in.blockSizes)) * int64(sq.sb.BlockSize)
+	if size := int64(in.fileSize); size < whole {
+		whole = size
+	}
+	if offset >= whole {
+		return 0, io.EOF
+	}
+	want := len(p)
+	if rem := whole - offset; int64(want) > rem {
+		p = p[:rem]
+	}
+	n := 0
+	for n < len(p) {
+		cur := offset + int64(n)
+		blk := int(cur / int64(sq.sb.BlockSize))
+		data, err := sq.blockData(int64(in.blocksStart), in.blockSizes, blk)
+		if err != nil {
+			return n, err
+		}
+		within := cur % int64(sq.sb.BlockSize)
+		if within >= int64(len(data)) {
+			break
+		}
+		n += copy(p[n:], data[within:])
+	}
+	if n < want {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// walk recursively expands the directory inode dir (whose own path is
+// name) into flat records, appending to recs and returning the
+// extended slice.
+func (sq *squashfsReader) walk(name string, dir *sqInode, recs []record) ([]record, error) {
+	if dir.dirSize < 3 {
+		return recs, nil
+	}
+	mr, err := newMetaReader(sq.dirCache, int64(sq.sb.DirectoryTableStart)+int64(dir.dirBlock), dir.dirOffset)
+	if err != nil {
+		return nil, err
+	}
+	remaining := int(dir.dirSize) - 3
+	for remaining > 0 {
+		count, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		startBlock, err := mr.u32()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := mr.u32(); err != nil { // header inode number base; unused, each entry carries its own
+			return nil, err
+		}
+		remaining -= 12
+		for i := uint32(0); i <= count; i++ {
+			off, err := mr.u16()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := mr.u16(); err != nil { // inode number delta; unused
+				return nil, err
+			}
+			if _, err := mr.u16(); err != nil { // entry type hint; unused, we read the real inode below
+				return nil, err
+			}
+			nameSize, err := mr.u16()
+			if err != nil {
+				return nil, err
+			}
+			nameBytes, err := mr.bytes(int(nameSize) + 1)
+			if err != nil {
+				return nil, err
+			}
+			remaining -= 8 + len(nameBytes)
+
+			child, err := sq.readInode(startBlock, off)
+			if err != nil {
+				return nil, err
+			}
+			childPath := filepath.Join(name, string(nameBytes))
+			r := record{info: recordInfo{Name: childPath, Mode: child.mode, UID: child.uid, GID: child.gid, MTime: child.mtime}}
+			switch child.kind {
+			case sqInodeBasicDir:
+				r.info.NLink = 2
+				r.info.Size = uint64(child.dirSize)
+			case sqInodeBasicFile:
+				r.info.NLink = 1
+				r.info.Size = child.fileSize
+				in := child
+				r.readAt = func(p []byte, offset int64) (int, error) { return in.readAt(sq, p, offset) }
+			case sqInodeBasicSymlink:
+				r.info.NLink = 1
+				r.info.Size = uint64(len(child.target))
+				r.link = child.target
+			default:
+				r.info.NLink = 1
+			}
+			recs = append(recs, r)
+
+			if child.kind == sqInodeBasicDir {
+				if recs, err = sq.walk(childPath, child, recs); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return recs, nil
+}
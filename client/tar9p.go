@@ -0,0 +1,114 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// NewTAR9P returns a read-only p9.Attacher over the POSIX/GNU tar
+// archive at path c, built the same way NewCPIO9P builds one over a
+// cpio archive: stream the archive once to index every entry's name,
+// attributes, and data offset, then serve ReadAt straight off the
+// still-open file instead of buffering contents in memory.
+func NewTAR9P(c string) (*recordFS, error) {
+	f, err := os.Open(c)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := tar.NewReader(f)
+	var recs []record
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Data for this entry starts wherever the tar reader's
+		// underlying offset sits right after Next returns its
+		// header, and runs for hdr.Size bytes; after that Next
+		// will skip past any padding on its own.
+		start, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(filepath.Clean("/"+hdr.Name), "/")
+		if name == "" {
+			name = "/"
+		}
+
+		r := record{info: recordInfo{
+			Name:  name,
+			Mode:  tarMode(hdr),
+			UID:   uint64(hdr.Uid),
+			GID:   uint64(hdr.Gid),
+			NLink: 1,
+			Size:  uint64(hdr.Size),
+			MTime: uint64(hdr.ModTime.Unix()),
+		}}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			r.info.NLink = 2
+		case tar.TypeSymlink, tar.TypeLink:
+			r.link = hdr.Linkname
+		case tar.TypeReg, tar.TypeRegA:
+			r.readAt = func(p []byte, offset int64) (int, error) {
+				return f.ReadAt(p, start+offset)
+			}
+		}
+		recs = append(recs, r)
+	}
+	if len(recs) == 0 {
+		return nil, fmt.Errorf("%s: no records: %w", c, os.ErrInvalid)
+	}
+	if i, ok := indexOf(recs, "/"); !ok {
+		recs = append([]record{{info: recordInfo{Name: "/", Mode: uint64(syscall.S_IFDIR) | 0o755, NLink: 2}}}, recs...)
+	} else if i != 0 {
+		recs[0], recs[i] = recs[i], recs[0]
+	}
+	return newRecordFS(recs), nil
+}
+
+func indexOf(recs []record, name string) (int, bool) {
+	for i, r := range recs {
+		if r.info.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// tarMode translates a tar header's type and permission bits into the
+// POSIX mode word recordFID.GetAttr expects, the same encoding
+// cpio.Info.Mode already uses.
+func tarMode(hdr *tar.Header) uint64 {
+	mode := uint64(hdr.Mode) & 0o7777
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		mode |= uint64(syscall.S_IFDIR)
+	case tar.TypeSymlink:
+		mode |= uint64(syscall.S_IFLNK)
+	case tar.TypeChar:
+		mode |= uint64(syscall.S_IFCHR)
+	case tar.TypeBlock:
+		mode |= uint64(syscall.S_IFBLK)
+	case tar.TypeFifo:
+		mode |= uint64(syscall.S_IFIFO)
+	default:
+		mode |= uint64(syscall.S_IFREG)
+	}
+	return mode
+}
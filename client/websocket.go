@@ -0,0 +1,75 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"net"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DialWebsocket dials url (e.g. "wss://host/cpu") and returns a net.Conn
+// wrapping the resulting WebSocket, so that a cpu client can speak its
+// usual SSH handshake over it exactly as it would over a dialed TCP
+// connection. This is the client-side counterpart of
+// server.WebsocketHandler, for reaching a cpud that sits behind an
+// HTTPS reverse proxy rather than exposing a raw TCP port.
+func DialWebsocket(url string) (net.Conn, error) {
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newWSClientConn(c), nil
+}
+
+// wsClientConn is the client-side mirror of the server package's
+// wsConn: it adapts a *websocket.Conn's message framing to the
+// net.Conn stream interface.
+type wsClientConn struct {
+	c       *websocket.Conn
+	readBuf []byte
+}
+
+func newWSClientConn(c *websocket.Conn) *wsClientConn {
+	return &wsClientConn{c: c}
+}
+
+func (w *wsClientConn) Read(b []byte) (int, error) {
+	for len(w.readBuf) == 0 {
+		mt, data, err := w.c.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		if mt != websocket.BinaryMessage {
+			continue
+		}
+		w.readBuf = data
+	}
+	n := copy(b, w.readBuf)
+	w.readBuf = w.readBuf[n:]
+	return n, nil
+}
+
+func (w *wsClientConn) Write(b []byte) (int, error) {
+	if err := w.c.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *wsClientConn) Close() error         { return w.c.Close() }
+func (w *wsClientConn) LocalAddr() net.Addr  { return w.c.LocalAddr() }
+func (w *wsClientConn) RemoteAddr() net.Addr { return w.c.RemoteAddr() }
+
+func (w *wsClientConn) SetDeadline(t time.Time) error {
+	if err := w.c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return w.c.SetWriteDeadline(t)
+}
+
+func (w *wsClientConn) SetReadDeadline(t time.Time) error  { return w.c.SetReadDeadline(t) }
+func (w *wsClientConn) SetWriteDeadline(t time.Time) error { return w.c.SetWriteDeadline(t) }
@@ -0,0 +1,102 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// NewZip9P returns a read-only p9.Attacher over the zip archive at
+// path c. Unlike NewTAR9P, zip entries are (usually) compressed, so
+// there is no contiguous byte range in the archive file to hand
+// directly to ReadAt; instead every ReadAt opens the entry through
+// archive/zip and inflates it from the start, so no entry content is
+// held in memory between reads, at the cost of re-inflating the entry
+// on each call.
+func NewZip9P(c string) (*recordFS, error) {
+	f, err := os.Open(c)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	zr, err := zip.NewReader(f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	dirs := map[string]bool{"/": true}
+	var recs []record
+	for _, zf := range zr.File {
+		name := strings.TrimSuffix(filepath.Clean("/"+zf.Name), "/")
+		if name == "" {
+			name = "/"
+		}
+		for d := filepath.Dir(name); d != "/" && !dirs[d]; d = filepath.Dir(d) {
+			dirs[d] = true
+			recs = append(recs, record{info: recordInfo{Name: d, Mode: uint64(syscall.S_IFDIR) | 0o755, NLink: 2}})
+		}
+
+		mode := zf.Mode()
+		r := record{info: recordInfo{
+			Name:  name,
+			UID:   0,
+			GID:   0,
+			NLink: 1,
+			Size:  zf.UncompressedSize64,
+			MTime: uint64(zf.Modified.Unix()),
+		}}
+		switch {
+		case mode.IsDir():
+			dirs[name] = true
+			r.info.Mode = uint64(syscall.S_IFDIR) | uint64(mode.Perm())
+			r.info.NLink = 2
+		case mode&os.ModeSymlink != 0:
+			r.info.Mode = uint64(syscall.S_IFLNK) | uint64(mode.Perm())
+			target, err := readZipFile(zf)
+			if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("%s: read symlink %s: %w", c, zf.Name, err)
+			}
+			r.link = string(target)
+		default:
+			r.info.Mode = uint64(syscall.S_IFREG) | uint64(mode.Perm())
+			zf := zf // capture for the closure below
+			r.readAt = func(p []byte, offset int64) (int, error) {
+				data, err := readZipFile(zf)
+				if err != nil {
+					return 0, err
+				}
+				return bytes.NewReader(data).ReadAt(p, offset)
+			}
+		}
+		recs = append(recs, r)
+	}
+	if len(recs) == 0 && len(zr.File) == 0 {
+		return nil, fmt.Errorf("%s: no records: %w", c, os.ErrInvalid)
+	}
+	recs = append([]record{{info: recordInfo{Name: "/", Mode: uint64(syscall.S_IFDIR) | 0o755, NLink: 2}}}, recs...)
+	return newRecordFS(recs), nil
+}
+
+func readZipFile(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,37 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+// ACL restricts which SSH public key fingerprints may be Resolved to a
+// node carrying the label Label=Value. A node whose labels match no
+// ACL entry is unrestricted: ACLs are opt-in per label, not a default
+// deny, since most clusters only need to lock down a few sensitive
+// labels (e.g. "env=prod").
+type ACL struct {
+	Label        string
+	Value        string
+	Fingerprints []string
+}
+
+// Allowed reports whether fingerprint may be resolved to a node with
+// the given labels, checked against acls. A node matches an ACL entry
+// if labels[entry.Label] == entry.Value; if any entry matches, the
+// fingerprint must appear in at least one matching entry's
+// Fingerprints. A node matching no entry at all is allowed.
+func Allowed(acls []ACL, labels map[string]string, fingerprint string) bool {
+	matched := false
+	for _, a := range acls {
+		if labels[a.Label] != a.Value {
+			continue
+		}
+		matched = true
+		for _, fp := range a.Fingerprints {
+			if fp == fingerprint {
+				return true
+			}
+		}
+	}
+	return !matched
+}
@@ -0,0 +1,43 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cluster implements the label-selector syntax and ACL checks a
+// cpu-broker uses to pick one node out of a fleet of registered cpuds.
+package cluster
+
+import "strings"
+
+// Selector is a set of label=value constraints, parsed from cpu's
+// "//key=value,key2=value2" fleet-addressing syntax. A Node matches a
+// Selector only if every constraint is present and equal in its
+// labels.
+type Selector map[string]string
+
+// ParseSelector parses the comma-separated key=value pairs of s. A
+// leading "//" is stripped if present, so both the bare
+// "key=value,key2=value2" form and cpu's "//key=value,key2=value2"
+// command-line form parse the same way. An empty s yields an empty
+// Selector, which Match treats as matching every node.
+func ParseSelector(s string) Selector {
+	s = strings.TrimPrefix(s, "//")
+	sel := Selector{}
+	if s == "" {
+		return sel
+	}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, _ := strings.Cut(kv, "=")
+		sel[k] = v
+	}
+	return sel
+}
+
+// Match reports whether labels satisfies every constraint in sel.
+func (sel Selector) Match(labels map[string]string) bool {
+	for k, v := range sel {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
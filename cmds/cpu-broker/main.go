@@ -0,0 +1,330 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command cpu-broker lets a fleet of cpuds register themselves under a
+// name and a set of labels, so a cpu client can resolve cpu's
+// "//key=value,..." selector syntax to a concrete node instead of
+// needing to know every cpud's address up front. Brokers gossip their
+// locally-registered nodes to each other over -peers, so a client
+// talking to any one broker sees the whole fleet, not just the nodes
+// that happen to have registered with it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/u-root/cpu/cluster"
+	pb "github.com/u-root/cpu/cluster/clusterpb"
+	"github.com/u-root/cpu/grpctransport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	addr  = flag.String("addr", ":17020", "address cpu-broker listens on for cpud registrations, Gossip, and client RPCs")
+	peers = flag.String("peers", "", "comma-separated addresses of other cpu-brokers to gossip with")
+
+	staleAfter = flag.Duration("stale", 90*time.Second, "how long a node may go without a Heartbeat before it's dropped")
+
+	hostKey = flag.String("hostkey", "", "PEM host key for mTLS on -addr; insecure (and ACLs unenforceable) if empty")
+)
+
+// node is one registered or gossiped cpud, plus the bookkeeping a
+// broker needs to reap it if it goes quiet.
+type node struct {
+	pb.Node
+	lastSeen time.Time
+}
+
+// nodeTable is a name -> node map shared between directly-registered
+// nodes and ones learned about via Gossip. The two are kept in
+// separate tables (see broker.local/broker.replica) so a broker never
+// re-gossips a peer's nodes back to that same peer as if they were its
+// own, but both use this type.
+type nodeTable struct {
+	mu    sync.Mutex
+	nodes map[string]*node
+}
+
+func newNodeTable() *nodeTable { return &nodeTable{nodes: map[string]*node{}} }
+
+func (t *nodeTable) put(n pb.Node) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nodes[n.Name] = &node{Node: n, lastSeen: time.Now()}
+}
+
+func (t *nodeTable) remove(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.nodes, name)
+}
+
+func (t *nodeTable) reapStale(after time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	for name, n := range t.nodes {
+		if now.Sub(n.lastSeen) > after {
+			delete(t.nodes, name)
+		}
+	}
+}
+
+func (t *nodeTable) snapshot() []pb.Node {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]pb.Node, 0, len(t.nodes))
+	for _, n := range t.nodes {
+		out = append(out, n.Node)
+	}
+	return out
+}
+
+// broker implements pb.BrokerServer. local holds nodes registered
+// directly with this broker; replica holds nodes learned from peer
+// brokers over Gossip. List/Describe/Resolve see the union of both.
+type broker struct {
+	pb.UnimplementedBrokerServer
+
+	local   *nodeTable
+	replica *nodeTable
+	acls    []cluster.ACL
+}
+
+func newBroker(acls []cluster.ACL) *broker {
+	return &broker{local: newNodeTable(), replica: newNodeTable(), acls: acls}
+}
+
+func (b *broker) all() []pb.Node {
+	return append(b.local.snapshot(), b.replica.snapshot()...)
+}
+
+// Register is held open for a cpud's registration lifetime: the first
+// frame must be Hello, and every Heartbeat after that refreshes
+// lastSeen so reapStale doesn't drop it.
+func (b *broker) Register(stream pb.Broker_RegisterServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := in.GetHello()
+	if hello == nil {
+		return grpcInvalidFirstFrame("Register", in)
+	}
+	b.local.put(*hello)
+	defer b.local.remove(hello.Name)
+	log.Printf("cpu-broker: %q registered (labels %v, addr %s)", hello.Name, hello.Labels, hello.Addr)
+
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if hb := in.GetHeartbeat(); hb != nil {
+			n := *hello
+			n.Load = hb.Load
+			b.local.put(n)
+		}
+	}
+}
+
+// Gossip streams this broker's locally-registered nodes to the peer on
+// the other end, and merges whatever nodes the peer sends back into
+// replica. It never re-sends replica into Gossip, so a node's
+// registration doesn't bounce between more than two brokers.
+func (b *broker) Gossip(stream pb.Broker_GossipServer) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			for _, n := range b.local.snapshot() {
+				if err := stream.Send(&n); err != nil {
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		n, err := stream.Recv()
+		if err != nil {
+			<-done
+			return err
+		}
+		b.replica.put(*n)
+	}
+}
+
+func (b *broker) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	sel := cluster.ParseSelector(req.Selector)
+	var matched []*pb.Node
+	for _, n := range b.all() {
+		n := n
+		if sel.Match(n.Labels) {
+			matched = append(matched, &n)
+		}
+	}
+	return &pb.ListResponse{Nodes: matched}, nil
+}
+
+func (b *broker) Describe(ctx context.Context, req *pb.DescribeRequest) (*pb.DescribeResponse, error) {
+	for _, n := range b.all() {
+		if n.Name == req.Name {
+			n := n
+			return &pb.DescribeResponse{Node: &n}, nil
+		}
+	}
+	return nil, fmt.Errorf("cpu-broker: no node named %q", req.Name)
+}
+
+// Resolve picks the least-loaded node matching req.Selector, subject
+// to b.acls, the ACL list newBroker was given; main passes none and
+// has no flag to configure any, so the cpu-broker command as built
+// enforces no ACLs. The fingerprint ACLs are checked
+// against comes from the calling client's verified mTLS certificate
+// (via grpctransport.PeerFingerprint), not req.ClientFingerprint: that
+// field is client-supplied and has no cryptographic binding to the
+// connection it arrived on, so trusting it would let any client claim
+// any fingerprint and pass the ACL check for any node. If -hostkey
+// wasn't set, there's no peer certificate to read, and any ACL with
+// Fingerprints configured can never be satisfied.
+func (b *broker) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	sel := cluster.ParseSelector(req.Selector)
+	// fingerprint is left "" if the connection has no verified peer
+	// certificate to read one from (e.g. -hostkey wasn't set): that
+	// still lets unrestricted nodes (no matching ACL entry) resolve,
+	// the same as before, but can never satisfy an ACL that actually
+	// requires a specific fingerprint.
+	fingerprint, _ := grpctransport.PeerFingerprint(ctx)
+	var best *pb.Node
+	bestLoad := math.Inf(1)
+	for _, n := range b.all() {
+		n := n
+		if !sel.Match(n.Labels) {
+			continue
+		}
+		if !cluster.Allowed(b.acls, n.Labels, fingerprint) {
+			continue
+		}
+		if n.Load < bestLoad {
+			best, bestLoad = &n, n.Load
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("cpu-broker: no node matches selector %q for fingerprint %q", req.Selector, fingerprint)
+	}
+	return &pb.ResolveResponse{Node: best}, nil
+}
+
+func grpcInvalidFirstFrame(rpc string, in interface{}) error {
+	return fmt.Errorf("cpu-broker: first %s frame must be Hello, got %T", rpc, in)
+}
+
+// gossipWith dials peerAddr and runs Gossip with it for as long as the
+// process lives, reconnecting with backoff on any error -- the same
+// pattern cmds/gcpud/relay.Serve uses for a cpud's relay tunnel.
+func gossipWith(b *broker, peerAddr string) {
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+	for {
+		if err := gossipOnce(b, peerAddr); err != nil {
+			log.Printf("cpu-broker: gossip with %s: %v; reconnecting in %v", peerAddr, err, backoff)
+		}
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func gossipOnce(b *broker, peerAddr string) error {
+	conn, err := grpc.Dial(peerAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewBrokerClient(conn).Gossip(context.Background())
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			for _, n := range b.local.snapshot() {
+				if err := stream.Send(&n); err != nil {
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		n, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		b.replica.put(*n)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	b := newBroker(nil)
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("cpu-broker: listen %s: %v", *addr, err)
+	}
+
+	go func() {
+		for range time.Tick(*staleAfter / 3) {
+			b.local.reapStale(*staleAfter)
+			b.replica.reapStale(*staleAfter)
+		}
+	}()
+
+	for _, p := range strings.Split(*peers, ",") {
+		if p == "" {
+			continue
+		}
+		go gossipWith(b, p)
+	}
+
+	var opts []grpc.ServerOption
+	if *hostKey != "" {
+		creds, err := grpctransport.AnyClientCredentials(*hostKey)
+		if err != nil {
+			log.Fatalf("cpu-broker: grpctransport.AnyClientCredentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	gs := grpc.NewServer(opts...)
+	pb.RegisterBrokerServer(gs, b)
+	log.Fatal(gs.Serve(lis))
+}
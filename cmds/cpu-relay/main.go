@@ -0,0 +1,344 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command cpu-relay is a broker that NAT'd cpuds register with, so a
+// cpu client that can't reach them directly can still be routed to
+// one by name. A cpud behind NAT dials in on -cpud and holds that
+// connection open as a yamux tunnel; a cpu client dials in on
+// -client, names the cpud it wants, and cpu-relay opens a fresh
+// stream on that cpud's existing tunnel and splices the two together.
+// No new TCP dial back to the cpud is ever made: everything rides the
+// one connection the cpud initiated, which is what makes this work
+// through NAT and most firewalls.
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/yamux"
+	pb "github.com/u-root/cpu/cmds/gcpud/relaypb"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
+)
+
+var (
+	cpudAddr   = flag.String("cpud", ":7000", "address cpuds register on")
+	clientAddr = flag.String("client", ":7001", "address cpu clients dial in on")
+	drainTO    = flag.Duration("drain", 10*time.Second, "how long to let in-flight sessions finish on shutdown before closing them")
+
+	authorizedKeysFile = flag.String("authorized-keys", "", "authorized_keys-format file of host keys allowed to register; any registration is accepted, proof-of-possession only, if empty")
+)
+
+// nonceSize is the size of the Challenge nonce signed by a registering
+// cpud's host key; large enough that it can't be guessed or replayed
+// from a previous connection.
+const nonceSize = 32
+
+// loadAuthorizedKeys parses an authorized_keys-format file into a list
+// of keys Register will accept a registration from, the same format
+// and parsing grpctransport.loadAuthorizedKey uses for cpud's own
+// client allow-list.
+func loadAuthorizedKeys(path string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cpu-relay: read %s: %v", path, err)
+	}
+	var keys []ssh.PublicKey
+	for len(data) > 0 {
+		key, _, _, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("cpu-relay: parse %s: %v", path, err)
+		}
+		keys = append(keys, key)
+		data = rest
+	}
+	return keys, nil
+}
+
+// tunnel is one registered cpud: its yamux session, for opening new
+// session streams, plus the metadata a client might select on.
+type tunnel struct {
+	name   string
+	labels map[string]string
+	ys     *yamux.Session
+}
+
+// registry is the relay's name -> tunnel table. A second cpud
+// registering an existing name replaces the first, closing its
+// tunnel: that's the simplest correct behavior for a cpud that
+// reconnected after a network blip before the relay noticed the old
+// connection was dead.
+type registry struct {
+	mu     sync.Mutex
+	byName map[string]*tunnel
+}
+
+func newRegistry() *registry { return &registry{byName: map[string]*tunnel{}} }
+
+func (r *registry) put(t *tunnel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.byName[t.name]; ok && old != t {
+		old.ys.Close() //nolint:errcheck
+	}
+	r.byName[t.name] = t
+}
+
+func (r *registry) remove(t *tunnel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byName[t.name] == t {
+		delete(r.byName, t.name)
+	}
+}
+
+func (r *registry) get(name string) (*tunnel, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.byName[name]
+	return t, ok
+}
+
+// relayServer implements pb.RelayServer over one cpud's control
+// stream; Register blocks for the tunnel's lifetime.
+type relayServer struct {
+	pb.UnimplementedRelayServer
+	reg *registry
+	ys  *yamux.Session
+
+	// authorizedKeys, if non-empty, is the set of host keys Register
+	// accepts a registration's signature from; a nil list (-authorized-keys
+	// unset) accepts any key, so a registration still proves it holds
+	// the private key behind whatever public key it presents, but that
+	// key isn't checked against a pre-approved list.
+	authorizedKeys []ssh.PublicKey
+}
+
+// verifyHello checks that hello.Signature is nonce signed by the
+// private key behind hello.PublicKey, and, if authorizedKeys is
+// non-empty, that PublicKey is one of them. This is what stands
+// between "any process can register as any name" and an actual
+// identity check, the same role an ssh host key plays for the ssh+9p
+// path.
+func verifyHello(hello *pb.Hello, nonce []byte, authorizedKeys []ssh.PublicKey) error {
+	if len(hello.PublicKey) == 0 || len(hello.Signature) == 0 {
+		return fmt.Errorf("cpu-relay: Hello carries no host-key signature")
+	}
+	key, err := ssh.ParsePublicKey(hello.PublicKey)
+	if err != nil {
+		return fmt.Errorf("cpu-relay: parse Hello.PublicKey: %v", err)
+	}
+	var sig ssh.Signature
+	if err := ssh.Unmarshal(hello.Signature, &sig); err != nil {
+		return fmt.Errorf("cpu-relay: parse Hello.Signature: %v", err)
+	}
+	if err := key.Verify(nonce, &sig); err != nil {
+		return fmt.Errorf("cpu-relay: signature does not verify against Hello.PublicKey: %v", err)
+	}
+	if len(authorizedKeys) == 0 {
+		return nil
+	}
+	want := key.Marshal()
+	for _, a := range authorizedKeys {
+		if subtle.ConstantTimeCompare(a.Marshal(), want) == 1 {
+			return nil
+		}
+	}
+	return fmt.Errorf("cpu-relay: Hello.PublicKey is not in -authorized-keys")
+}
+
+func (s *relayServer) Register(stream pb.Relay_RegisterServer) error {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	if err := stream.Send(&pb.RelayFrame{Payload: &pb.RelayFrame_Challenge{Challenge: &pb.Challenge{Nonce: nonce}}}); err != nil {
+		return err
+	}
+
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := in.GetHello()
+	if hello == nil {
+		return fmt.Errorf("cpu-relay: second Register frame must be Hello, got %T", in.Payload)
+	}
+	if err := verifyHello(hello, nonce, s.authorizedKeys); err != nil {
+		return err
+	}
+	t := &tunnel{name: hello.Name, labels: hello.Labels, ys: s.ys}
+	s.reg.put(t)
+	defer s.reg.remove(t)
+	log.Printf("cpu-relay: %q registered (labels %v)", t.name, t.labels)
+
+	if err := stream.Send(&pb.RelayFrame{Payload: &pb.RelayFrame_Registered{Registered: &pb.Registered{Name: t.name}}}); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+// singleStreamListener serves a gRPC server over exactly one
+// already-open net.Conn (a yamux stream here), the same trick used by
+// cmds/gcpud/cpu to turn a dialed-out connection into a server.
+type singleStreamListener struct {
+	conn net.Conn
+	once sync.Once
+	done chan struct{}
+}
+
+func newSingleStreamListener(conn net.Conn) *singleStreamListener {
+	return &singleStreamListener{conn: conn, done: make(chan struct{})}
+}
+
+func (l *singleStreamListener) Accept() (net.Conn, error) {
+	var c net.Conn
+	l.once.Do(func() { c = l.conn })
+	if c != nil {
+		return c, nil
+	}
+	<-l.done
+	return nil, io.EOF
+}
+
+func (l *singleStreamListener) Close() error   { close(l.done); return l.conn.Close() }
+func (l *singleStreamListener) Addr() net.Addr { return l.conn.LocalAddr() }
+
+// serveCpud handles one cpud's inbound registration connection for
+// the rest of its life: it becomes a yamux server over conn so the
+// relay can later open new streams on it, and hosts the Relay control
+// service over the first stream cpud opens.
+func serveCpud(conn net.Conn, reg *registry, authorizedKeys []ssh.PublicKey) {
+	defer conn.Close()
+	ys, err := yamux.Server(conn, yamux.DefaultConfig())
+	if err != nil {
+		log.Printf("cpu-relay: yamux.Server: %v", err)
+		return
+	}
+	defer ys.Close()
+
+	ctrl, err := ys.Accept()
+	if err != nil {
+		log.Printf("cpu-relay: accepting control stream: %v", err)
+		return
+	}
+
+	gs := grpc.NewServer()
+	pb.RegisterRelayServer(gs, &relayServer{reg: reg, ys: ys, authorizedKeys: authorizedKeys})
+	gs.Serve(newSingleStreamListener(ctrl)) //nolint:errcheck
+}
+
+// serveClient reads the target cpud name the client wants (a single
+// newline-terminated line, matching what cmds/gcpud/cpu's -relay mode
+// sends), opens a fresh stream on that cpud's tunnel, and splices the
+// two connections together until either side closes.
+func serveClient(conn net.Conn, reg *registry, wg *sync.WaitGroup) {
+	defer wg.Done()
+	defer conn.Close()
+
+	br := bufio.NewReader(conn)
+	name, err := br.ReadString('\n')
+	if err != nil {
+		log.Printf("cpu-relay: reading target name: %v", err)
+		return
+	}
+	name = name[:len(name)-1]
+
+	t, ok := reg.get(name)
+	if !ok {
+		log.Printf("cpu-relay: no cpud registered as %q", name)
+		return
+	}
+	stream, err := t.ys.Open()
+	if err != nil {
+		log.Printf("cpu-relay: opening session stream to %q: %v", name, err)
+		return
+	}
+	defer stream.Close()
+
+	var splice sync.WaitGroup
+	splice.Add(2)
+	go func() { defer splice.Done(); io.Copy(stream, br) }() //nolint:errcheck
+	go func() { defer splice.Done(); io.Copy(conn, stream) }() //nolint:errcheck
+	splice.Wait()
+}
+
+func main() {
+	flag.Parse()
+
+	reg := newRegistry()
+
+	var authorizedKeys []ssh.PublicKey
+	if *authorizedKeysFile != "" {
+		keys, err := loadAuthorizedKeys(*authorizedKeysFile)
+		if err != nil {
+			log.Fatalf("cpu-relay: %v", err)
+		}
+		authorizedKeys = keys
+	}
+
+	cpudLis, err := net.Listen("tcp", *cpudAddr)
+	if err != nil {
+		log.Fatalf("cpu-relay: listen %s: %v", *cpudAddr, err)
+	}
+	clientLis, err := net.Listen("tcp", *clientAddr)
+	if err != nil {
+		log.Fatalf("cpu-relay: listen %s: %v", *clientAddr, err)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	go func() {
+		for {
+			conn, err := cpudLis.Accept()
+			if err != nil {
+				return
+			}
+			go serveCpud(conn, reg, authorizedKeys)
+		}
+	}()
+	go func() {
+		for {
+			conn, err := clientLis.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go serveClient(conn, reg, &wg)
+		}
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	close(stop)
+	log.Printf("cpu-relay: draining, waiting up to %v for in-flight sessions", *drainTO)
+	clientLis.Close() //nolint:errcheck
+	cpudLis.Close()   //nolint:errcheck
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(*drainTO):
+		log.Printf("cpu-relay: drain timed out, exiting anyway")
+	}
+}
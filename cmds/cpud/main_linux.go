@@ -0,0 +1,104 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command cpud is the cpu daemon: it serves the ssh+9p session path
+// (server.New), the grpctransport gRPC session path, and a raw 9P2000.L
+// gofer, all on the single port -mux opens, using a session.Muxer to
+// sniff each connection's first bytes and route it to the right one.
+// That replaces running three separate listeners (-sp for ssh, -addr
+// for gRPC, -port9p for 9p) with one that's easier to get through a
+// firewall or load balancer.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"github.com/u-root/cpu/client"
+	pb "github.com/u-root/cpu/cmds/gcpud/mess/cpu"
+	"github.com/u-root/cpu/grpctransport"
+	"github.com/u-root/cpu/server"
+	"github.com/u-root/cpu/session"
+	"google.golang.org/grpc"
+)
+
+var (
+	muxAddr = flag.String("mux", ":17010", "single address cpud listens on for ssh, gRPC, and 9P connections")
+
+	pubKeyFile  = flag.String("pk", "key.pub", "ssh authorized key file")
+	hostKeyFile = flag.String("hk", "", "ssh host key file")
+	grpcHostKey = flag.String("grpc-hostkey", "", "PEM host key for the gRPC path's mTLS; insecure if empty")
+
+	root = flag.String("root", "/", "root directory the 9P/gRPC path serves")
+
+	goferFD = flag.Int("gofer-fd", -1, "if >= 0, also serve 9P2000.L on this pre-connected fd (the runsc gofer convention) instead of only the muxed 9P listener")
+
+	debug = flag.Bool("d", false, "enable debug prints")
+)
+
+func main() {
+	flag.Parse()
+
+	l, err := net.Listen("tcp", *muxAddr)
+	if err != nil {
+		log.Fatalf("cpud: listen %s: %v", *muxAddr, err)
+	}
+	mux := session.NewMuxer(l)
+	sshLis := mux.MatchSSH()
+	grpcLis := mux.MatchGRPC()
+	nineLis := mux.Match9P()
+
+	// server.New has been built on gliderlabs/ssh since before this
+	// package existed -- auth, pty allocation, SIGWINCH, env, and ssh
+	// -R/-L forwarding all already go through it, and there has never
+	// been a second, from-scratch ssh implementation in this tree to
+	// pick between. A previous pass here added a "-ssh-backend
+	// gliderlabs|builtin" flag on that premise, but the "builtin" side
+	// never existed; the flag could only ever be rejected, so it's
+	// gone rather than kept around as a selector with one option.
+	sshSrv, err := server.New(*pubKeyFile, *hostKeyFile)
+	if err != nil {
+		log.Fatalf("cpud: server.New: %v", err)
+	}
+	go func() {
+		log.Fatalf("cpud: ssh: %v", sshSrv.Serve(sshLis))
+	}()
+
+	var opts []grpc.ServerOption
+	if *grpcHostKey != "" {
+		// Reuse -pk, the same authorized client key the ssh path
+		// checks in server.New, rather than asking for a second
+		// allow-list file for the same client.
+		creds, err := grpctransport.ServerCredentials(*grpcHostKey, *pubKeyFile)
+		if err != nil {
+			log.Fatalf("cpud: grpctransport.ServerCredentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	gs := grpc.NewServer(opts...)
+	pb.RegisterSessionServer(gs, grpctransport.NewServerWithAttacher(client.NewCPU9P(*root)))
+	go func() {
+		log.Fatalf("cpud: gRPC: %v", gs.Serve(grpcLis))
+	}()
+
+	go func() {
+		log.Fatalf("cpud: 9P: %v", server.ServeGofer(nineLis, server.NewUFS(*root)))
+	}()
+
+	if *goferFD >= 0 {
+		conn, err := server.GoferListener(*goferFD)
+		if err != nil {
+			log.Fatalf("cpud: -gofer-fd %d: %v", *goferFD, err)
+		}
+		go func() {
+			log.Fatalf("cpud: gofer fd %d: %v", *goferFD, server.ServeGoferConn(conn, server.NewUFS(*root)))
+		}()
+	}
+
+	if *debug {
+		log.Printf("cpud: listening on %v (ssh, gRPC, 9P multiplexed)", l.Addr())
+	}
+	log.Fatal(mux.Serve())
+}
@@ -8,6 +8,7 @@ import (
 	"flag"
 	"log"
 	"os"
+	"os/signal"
 	"runtime"
 	"syscall"
 
@@ -42,6 +43,7 @@ func verbose(f string, a ...interface{}) {
 func main() {
 	flag.CommandLine = flag.NewFlagSet("cpuns", flag.ExitOnError)
 	debug := flag.Bool("d", false, "enable debug prints")
+	noPTY := flag.Bool("T", false, "disable pty allocation, symmetric to ssh's -T")
 	flag.Parse()
 	if *debug {
 		v = log.Printf
@@ -60,7 +62,32 @@ func main() {
 	// good way to pass it (it is passed as as switch in cpud).
 	// That is ok, 9p has never been that good on Linux.
 	s := session.New("", args[0], args[1:]...)
+	if !*noPTY {
+		rows, cols := termSize(os.Stdin)
+		s.WithPTY(rows, cols, os.Getenv("TERM"))
+
+		winch := make(chan os.Signal, 1)
+		signal.Notify(winch, syscall.SIGWINCH)
+		go func() {
+			for range winch {
+				rows, cols := termSize(os.Stdin)
+				if err := s.Resize(rows, cols); err != nil {
+					verbose("resize: %v", err)
+				}
+			}
+		}()
+	}
 	if err := s.Run(); err != nil {
 		log.Fatalf("CPUD(remote): %v", err)
 	}
 }
+
+// termSize returns the current window size of f, or 0, 0 if it can't be
+// determined (e.g. f is not a tty).
+func termSize(f *os.File) (rows, cols uint16) {
+	ws, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0
+	}
+	return ws.Row, ws.Col
+}
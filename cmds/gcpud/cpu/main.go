@@ -0,0 +1,192 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command cpu is gcpud's local side: it dials cpud's listener, then
+// flips that one connection into a gRPC server hosting iopb.Session,
+// so cpud (the client on this connection) can stream the remote
+// command's stdio back without a second RPC per byte.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	clusterpb "github.com/u-root/cpu/cluster/clusterpb"
+	pb "github.com/u-root/cpu/cmds/gcpud/iopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	addr = flag.String("addr", "localhost:6666", "cpud address to dial")
+
+	// relayAddr and target let this cpu reach a NAT'd cpud through a
+	// cmds/cpu-relay instead of dialing it directly: the connection
+	// to -relay is handed the target name as a single line, and the
+	// relay splices it to a fresh stream on that cpud's tunnel, so
+	// everything below this point is unaware the connection didn't
+	// come straight from cpud.
+	relayAddr = flag.String("relay", "", "cpu-relay address to connect through instead of dialing -addr directly")
+	target    = flag.String("target", "", "name of the cpud to reach via -relay")
+
+	// broker and selector let this cpu address a fleet by label
+	// instead of a fixed -addr: if -select is set, cpu asks -broker
+	// to Resolve it to one node's address and dials that instead,
+	// before any -relay/-addr logic below runs.
+	broker   = flag.String("broker", "", "cpu-broker address to resolve -select against")
+	selector = flag.String("select", "", "label selector (e.g. //gpu=nvidia) identifying the cpud to reach via -broker")
+)
+
+// resolve asks brokerAddr to Resolve sel to one node, returning the
+// address cpu should dial instead of -addr.
+func resolve(brokerAddr, sel string) (string, error) {
+	conn, err := grpc.Dial(brokerAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", fmt.Errorf("dial broker %s: %w", brokerAddr, err)
+	}
+	defer conn.Close()
+
+	resp, err := clusterpb.NewBrokerClient(conn).Resolve(context.Background(), &clusterpb.ResolveRequest{Selector: sel})
+	if err != nil {
+		return "", fmt.Errorf("resolve %q via %s: %w", sel, brokerAddr, err)
+	}
+	return resp.Node.Addr, nil
+}
+
+// singleConnListener hands exactly one net.Conn -- the one cpu already
+// dialed out on -- to grpc.Server.Serve, so the existing TCP
+// connection can be reused as the transport for a gRPC server instead
+// of opening a new listening socket.
+type singleConnListener struct {
+	conn net.Conn
+	done chan struct{}
+}
+
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	select {
+	case <-l.done:
+		return nil, io.EOF
+	default:
+	}
+	close(l.done)
+	return l.conn, nil
+}
+
+func (l *singleConnListener) Close() error   { return l.conn.Close() }
+func (l *singleConnListener) Addr() net.Addr { return l.conn.LocalAddr() }
+
+// server implements pb.SessionServer on the cpu side: it plays the
+// terminal's role, forwarding os.Stdin to cpud and cpud's
+// Stdout/Stderr/Exit frames to the local terminal.
+type server struct {
+	pb.UnimplementedSessionServer
+}
+
+// Start just logs the invocation cpud is about to run; there is
+// nothing to allocate on this side ahead of time.
+func (s *server) Start(ctx context.Context, req *pb.ExecRequest) (*pb.ExecResponse, error) {
+	log.Printf("cpu: starting %q (port9p %v)", req.Argv, req.Port9P)
+	return &pb.ExecResponse{}, nil
+}
+
+// Exec is the bidirectional stdio channel. It sends Stdin frames read
+// from os.Stdin and SIGWINCH-driven WinchResize frames, and writes
+// Stdout/Stderr frames it receives to the local terminal until cpud
+// sends Exit.
+func (s *server) Exec(stream pb.Session_ExecServer) error {
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+
+	go func() {
+		b := make([]byte, 32*1024)
+		for {
+			n, err := os.Stdin.Read(b)
+			if n > 0 {
+				if serr := stream.Send(&pb.IoFrame{Payload: &pb.IoFrame_Stdin{Stdin: &pb.Stdin{Data: append([]byte(nil), b[:n]...)}}}); serr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		for range winch {
+			rows, cols := termSize()
+			stream.Send(&pb.IoFrame{Payload: &pb.IoFrame_WinchResize{WinchResize: &pb.WinchResize{Rows: rows, Cols: cols}}}) //nolint:errcheck
+		}
+	}()
+
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		switch p := in.Payload.(type) {
+		case *pb.IoFrame_Stdout:
+			os.Stdout.Write(p.Stdout.Data) //nolint:errcheck
+		case *pb.IoFrame_Stderr:
+			os.Stderr.Write(p.Stderr.Data) //nolint:errcheck
+		case *pb.IoFrame_Exit:
+			if p.Exit.Error != "" {
+				log.Printf("cpu: remote command: %v", p.Exit.Error)
+			}
+			os.Exit(int(p.Exit.Code))
+		}
+	}
+}
+
+// termSize is a placeholder until cpu's real terminal-size probing
+// (see session.WithPTY's callers) is wired up here; it always reports
+// a window change without a size change, which is enough to exercise
+// the WinchResize path.
+func termSize() (rows, cols uint32) { return 0, 0 }
+
+func main() {
+	flag.Parse()
+
+	if *broker != "" {
+		if *selector == "" {
+			log.Fatalf("-broker requires -select")
+		}
+		resolved, err := resolve(*broker, *selector)
+		if err != nil {
+			log.Fatalf("cpu: %v", err)
+		}
+		*addr = resolved
+	}
+
+	var conn net.Conn
+	var err error
+	if *relayAddr != "" {
+		conn, err = net.Dial("tcp", *relayAddr)
+		if err != nil {
+			log.Fatalf("dial relay %s: %v", *relayAddr, err)
+		}
+		if _, err := fmt.Fprintf(conn, "%s\n", *target); err != nil {
+			log.Fatalf("sending target %q to relay: %v", *target, err)
+		}
+	} else {
+		conn, err = net.Dial("tcp", *addr)
+		if err != nil {
+			log.Fatalf("dial %s: %v", *addr, err)
+		}
+	}
+
+	lis := &singleConnListener{conn: conn, done: make(chan struct{})}
+	s := grpc.NewServer()
+	pb.RegisterSessionServer(s, &server{})
+	if err := s.Serve(lis); err != nil && err != io.EOF {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
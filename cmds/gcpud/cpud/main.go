@@ -0,0 +1,210 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command cpud is gcpud's remote side: for each connection it gets --
+// whether from a direct accept on -sp or a tunnel stream handed to it
+// by -register's relay -- it flips that one connection into a gRPC
+// client talking iopb.Session, and runs the command locally via
+// session.Session, streaming its stdio back over that one connection
+// instead of a second ssh-style channel.
+//
+// This replaces the helloworld.Greeter sketch that used to live here:
+// that code polled Stdin/SayHello a byte at a time on a 60s timeout
+// per call, and never had a stdio path other than a net.Pipe wired to
+// those RPCs. iopb.Session (cmds/gcpud/iopb) is the real API.
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	pb "github.com/u-root/cpu/cmds/gcpud/iopb"
+	"github.com/u-root/cpu/cmds/gcpud/relay"
+	"github.com/u-root/cpu/session"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	port = flag.String("sp", ":6666", "cpu default port")
+
+	debug  = flag.Bool("d", false, "enable debug prints")
+	remote = flag.Bool("remote", true, "indicates we are the remote side of the cpu session")
+	port9p = flag.String("port9p", "", "port9p # on remote machine for 9p mount")
+	klog   = flag.Bool("klog", false, "Log cpud messages in kernel log, not stdout")
+
+	// Some networks are not well behaved, and for them we implement registration.
+	registerAddr    = flag.String("register", "", "address and port of a cpu-relay to register with, instead of accepting connections directly")
+	registerName    = flag.String("registerName", "", "name to advertise to the relay; defaults to the hostname")
+	registerTO      = flag.Duration("registerTO", 5*time.Second, "time.Duration for Dial address for registering")
+	registerHostKey = flag.String("registerHostKey", "", "PEM host key signing -register's challenge to authenticate this cpud to the relay; rejected by a relay enforcing -authorized-keys if empty")
+
+	// v allows debug printing.
+	// Do not call it directly, call verbose instead.
+	v = func(string, ...interface{}) {}
+)
+
+func verbose(f string, a ...interface{}) {
+	v("CPUD(remote):"+f+"\r\n", a...)
+}
+
+func main() {
+	flag.Parse()
+	if *debug {
+		v = log.Printf
+		session.SetVerbose(verbose)
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		log.Fatalf("CPUD(remote): no command given")
+	}
+
+	handler := func(conn net.Conn) {
+		if err := serveConn(conn, args, *port9p); err != nil {
+			verbose("session: %v", err)
+		}
+	}
+
+	if *registerAddr != "" {
+		name := *registerName
+		if name == "" {
+			if h, err := os.Hostname(); err == nil {
+				name = h
+			}
+		}
+		log.Fatal(relay.Serve(*registerAddr, name, nil, *registerTO, *registerHostKey, handler))
+	}
+
+	lis, err := net.Listen("tcp", *port)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			log.Fatalf("accept: %v", err)
+		}
+		go handler(conn)
+	}
+}
+
+// serveConn drives one cpu session over conn: conn is either the TCP
+// connection cpu dialed in on directly, or a tunnel stream a cpu-relay
+// handed back to us on behalf of a cpu client it isn't on the same
+// connection as. Either way cpud is the iopb.Session gRPC client and
+// cpu, on the other end, is the server.
+func serveConn(conn net.Conn, argv []string, port9p string) error {
+	gc, err := grpc.Dial("cpud", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return conn, nil
+	}))
+	if err != nil {
+		return err
+	}
+	defer gc.Close()
+	c := pb.NewSessionClient(gc)
+
+	verbose("args %q, port9p %v", argv, port9p)
+	s := session.New(port9p, argv[0], argv[1:]...)
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+	s.Stdin, s.Stdout, s.Stderr = stdinR, stdoutW, stderrW
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := c.Start(ctx, &pb.ExecRequest{Argv: argv, Port9P: port9p}); err != nil {
+		return err
+	}
+
+	stream, err := c.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Inbound: frames from cpu become bytes on stdinW. When the stream
+	// fails, ctx is cancelled and stdinW is closed with the error;
+	// s.Run takes no context, so the running command is not killed and
+	// only sees its stdin end.
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				cancel()
+				stdinW.CloseWithError(err) //nolint:errcheck
+				return
+			}
+			switch p := in.Payload.(type) {
+			case *pb.IoFrame_Stdin:
+				if _, err := stdinW.Write(p.Stdin.Data); err != nil {
+					cancel()
+					return
+				}
+			case *pb.IoFrame_WinchResize:
+				if p.WinchResize.Rows == 0 && p.WinchResize.Cols == 0 {
+					// A 0x0 report carries no size; the pty keeps its own.
+					break
+				}
+				if err := s.Resize(uint16(p.WinchResize.Rows), uint16(p.WinchResize.Cols)); err != nil {
+					verbose("resize: %v", err)
+				}
+			case *pb.IoFrame_Signal:
+				verbose("signal %d from cpu: no process to deliver it to yet", p.Signal.Num)
+			}
+		}
+	}()
+
+	// Outbound: copy s.Stdout/s.Stderr to the stream as Stdout/Stderr
+	// frames, replacing the old one-SayHello-call-per-byte loop.
+	var outWG sync.WaitGroup
+	outWG.Add(2)
+	go copyOut(&outWG, stream, stdoutR, func(b []byte) *pb.IoFrame {
+		return &pb.IoFrame{Payload: &pb.IoFrame_Stdout{Stdout: &pb.Stdout{Data: b}}}
+	})
+	go copyOut(&outWG, stream, stderrR, func(b []byte) *pb.IoFrame {
+		return &pb.IoFrame{Payload: &pb.IoFrame_Stderr{Stderr: &pb.Stderr{Data: b}}}
+	})
+
+	runErr := s.Run()
+	// Closing the writers lets both copyOut calls reach EOF; waiting for
+	// them puts the last output on the stream before Exit.
+	stdoutW.Close()
+	stderrW.Close()
+	outWG.Wait()
+	exit := &pb.Exit{}
+	if runErr != nil {
+		exit.Error = runErr.Error()
+		exit.Code = -1
+	}
+	stream.Send(&pb.IoFrame{Payload: &pb.IoFrame_Exit{Exit: exit}}) //nolint:errcheck
+	stream.CloseSend()                                              //nolint:errcheck
+	return runErr
+}
+
+// copyOut reads r in chunks and sends each as a frame built by wrap,
+// until r hits EOF (serveConn closes the corresponding pipe writer once
+// s.Run returns) or stream.Send fails because cpu hung up.
+func copyOut(wg *sync.WaitGroup, stream pb.Session_ExecClient, r io.Reader, wrap func([]byte) *pb.IoFrame) {
+	defer wg.Done()
+	b := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if serr := stream.Send(wrap(append([]byte(nil), b[:n]...))); serr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -3,74 +3,55 @@ package main
 //go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative cpu.proto
 
 import (
-	"context"
-	"io"
+	"flag"
 	"log"
 	"net"
-	"sync"
-
-	"pb"
 
+	pb "github.com/u-root/cpu/cmds/gcpud/mess/cpu"
+	"github.com/u-root/cpu/client"
+	"github.com/u-root/cpu/grpctransport"
 	"google.golang.org/grpc"
 )
 
-type server struct{}
-
-func (s server) FetchResponse(in *pb.Request, srv pb.StreamService_FetchResponseServer) error {
+var (
+	addr     = flag.String("addr", ":50005", "listen address")
+	nineAddr = flag.String("nine", "127.0.0.1:5640", "address of the local 9p server to relay NineP to")
+	nineRoot = flag.String("nineroot", "", "serve 9p directly from this directory over the same connection, instead of relaying to -nine")
+	hostKey  = flag.String("hostkey", "", "PEM host key for mTLS; insecure if empty")
+	pubKey   = flag.String("pk", "key.pub", "authorized client key file for mTLS; only used if -hostkey is set")
+)
 
-	log.Printf("fetch response for id : %d", in.Id)
+func main() {
+	flag.Parse()
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
 
-	var wg sync.WaitGroup
-	{
-		wg.Add(2)
-		in := &pb.Request{Id: 1}
-		stream, err := client.FetchResponse(context.Background(), in)
+	var opts []grpc.ServerOption
+	if *hostKey != "" {
+		creds, err := grpctransport.ServerCredentials(*hostKey, *pubKey)
 		if err != nil {
-			log.Fatalf("openn stream error %v", err)
+			log.Fatalf("grpctransport.ServerCredentials: %v", err)
 		}
-
-		//ctx := stream.Context()
-		done := make(chan bool)
-
-		go func() {
-			for {
-				resp, err := stream.Recv()
-				if err == io.EOF {
-					done <- true //close(done)
-					return
-				}
-				if err != nil {
-					log.Fatalf("can not receive %v", err)
-				}
-				log.Printf("Resp received: %s", resp.Result)
-			}
-		}()
-	}
-	if _, err := stdin.Write([]byte("date\n")); err != nil {
-		log.Printf("write command: %v", err)
+		opts = append(opts, grpc.Creds(creds))
 	}
-	if err := c.Run(); err != nil {
-		log.Printf("run: %v", err)
-	}
-	wg.Wait()
-	return nil
-}
 
-func main() {
-	// create listiner
-	lis, err := net.Listen("tcp", ":50005")
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+	var gs *grpctransport.Server
+	if *nineRoot != "" {
+		// One connection carries both the Exec session and the
+		// reverse-mounted namespace: no separate 9p listener or
+		// relay hop needed.
+		gs = grpctransport.NewServerWithAttacher(client.NewCPU9P(*nineRoot))
+	} else {
+		gs = grpctransport.NewServer(*nineAddr)
 	}
 
-	// create grpc server
-	s := grpc.NewServer()
-	pb.RegisterStreamServiceServer(s, server{})
+	s := grpc.NewServer(opts...)
+	pb.RegisterSessionServer(s, gs)
 
-	log.Println("start server")
-	// and start...
+	log.Printf("gcpud: listening on %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
-
 }
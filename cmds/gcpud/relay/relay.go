@@ -0,0 +1,165 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package relay is cpud's half of the -register reverse-tunnel: it
+// dials a cpu-relay, holds the connection open as a yamux session so
+// the relay can open new streams back on it as cpu clients arrive
+// asking for this cpud by name, and reconnects with backoff if the
+// tunnel drops.
+package relay
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hashicorp/yamux"
+	pb "github.com/u-root/cpu/cmds/gcpud/relaypb"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const heartbeatInterval = 30 * time.Second
+
+// Serve registers name/labels with the cpu-relay at addr and calls
+// handler, in its own goroutine, for every session stream the relay
+// subsequently opens on the tunnel. hostKeyFile, if non-empty, is a PEM
+// ed25519/rsa key (the same format cpud's other host key flags use)
+// signed over the relay's Challenge nonce to prove this registration
+// actually comes from whoever holds that key; an empty hostKeyFile
+// registers unauthenticated, accepted only by a relay that isn't
+// enforcing -authorized-keys. It never returns on its own: a dropped
+// tunnel or registration error is logged and followed by a reconnect
+// attempt with exponential backoff, capped at 30s, so a relay restart
+// or a flaky NAT path doesn't require restarting cpud.
+func Serve(addr, name string, labels map[string]string, dialTimeout time.Duration, hostKeyFile string, handler func(net.Conn)) error {
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+	for {
+		err := connectOnce(addr, name, labels, dialTimeout, hostKeyFile, handler)
+		log.Printf("relay: tunnel to %s for %q: %v; reconnecting in %v", addr, name, err, backoff)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// loadSigner reads an ed25519 or RSA private key from a PEM file and
+// returns an ssh.Signer for it, the same key format/parsing
+// grpctransport.loadPrivateKey uses for cpud's other host keys.
+func loadSigner(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("relay: read host key %s: %v", path, err)
+	}
+	key, err := ssh.ParseRawPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("relay: parse host key %s: %v", path, err)
+	}
+	return ssh.NewSignerFromKey(key)
+}
+
+// connectOnce dials addr, registers name/labels over a control stream
+// multiplexed alongside the session streams, and accepts session
+// streams until the tunnel breaks.
+func connectOnce(addr, name string, labels map[string]string, dialTimeout time.Duration, hostKeyFile string, handler func(net.Conn)) error {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ys, err := yamux.Client(conn, yamux.DefaultConfig())
+	if err != nil {
+		return err
+	}
+	defer ys.Close()
+
+	ctrl, err := ys.Open()
+	if err != nil {
+		return err
+	}
+	gc, err := grpc.Dial("relay", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return ctrl, nil
+	}))
+	if err != nil {
+		return err
+	}
+	defer gc.Close()
+
+	stream, err := pb.NewRelayClient(gc).Register(context.Background())
+	if err != nil {
+		return err
+	}
+
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	challenge := in.GetChallenge()
+	if challenge == nil {
+		return fmt.Errorf("relay: expected Challenge, got %T", in.Payload)
+	}
+
+	hello := &pb.Hello{Name: name, Labels: labels}
+	if hostKeyFile != "" {
+		signer, err := loadSigner(hostKeyFile)
+		if err != nil {
+			return err
+		}
+		sig, err := signer.Sign(rand.Reader, challenge.Nonce)
+		if err != nil {
+			return fmt.Errorf("relay: signing challenge: %v", err)
+		}
+		hello.PublicKey = signer.PublicKey().Marshal()
+		hello.Signature = ssh.Marshal(sig)
+	}
+	if err := stream.Send(&pb.RelayFrame{Payload: &pb.RelayFrame_Hello{Hello: hello}}); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go heartbeat(stream, done)
+	go func() {
+		// Drain Registered/ack frames; a Recv error (relay closed
+		// the control stream) means the tunnel is dead even if
+		// yamux hasn't noticed yet, so tear it down.
+		for {
+			if _, err := stream.Recv(); err != nil {
+				ys.Close() //nolint:errcheck
+				return
+			}
+		}
+	}()
+
+	for {
+		s, err := ys.Accept()
+		if err != nil {
+			return err
+		}
+		go handler(s)
+	}
+}
+
+func heartbeat(stream pb.Relay_RegisterClient, done <-chan struct{}) {
+	t := time.NewTicker(heartbeatInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			if err := stream.Send(&pb.RelayFrame{Payload: &pb.RelayFrame_Heartbeat{Heartbeat: &pb.Heartbeat{}}}); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpctransport
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	pb "github.com/u-root/cpu/cmds/gcpud/mess/cpu"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Client is a cpu client's handle on a cpud reached over grpctransport,
+// the counterpart of dialing ssh+9p: one Exec call drives the remote
+// command, and NineP gives back a net.Conn carrying 9P, which the
+// session package's dialGRPC9P wraps in a p9.Client;
+// session.Session.Namespace itself still dials s.port9p over TCP.
+type Client struct {
+	conn *grpc.ClientConn
+	c    pb.SessionClient
+}
+
+// Dial connects to a cpud's grpctransport listener at addr.
+func Dial(addr string, creds credentials.TransportCredentials) (*Client, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, c: pb.NewSessionClient(conn)}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Exec runs argv remotely, streaming stdin to the command and copying
+// its stdout/stderr to the given writers, and returns its exit code.
+func (c *Client) Exec(ctx context.Context, argv, env []string, cwd string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	stream, err := c.c.Exec(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if err := stream.Send(&pb.Frame{Payload: &pb.Frame_Start{Start: &pb.Start{
+		Argv: argv,
+		Env:  env,
+		Cwd:  cwd,
+	}}}); err != nil {
+		return -1, err
+	}
+
+	go func() {
+		b := make([]byte, 32*1024)
+		for {
+			n, err := stdin.Read(b)
+			if n > 0 {
+				if serr := stream.Send(&pb.Frame{Payload: &pb.Frame_Stdin{Stdin: &pb.Stdin{Data: append([]byte(nil), b[:n]...)}}}); serr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			return -1, err
+		}
+		switch p := in.Payload.(type) {
+		case *pb.Frame_Stdout:
+			stdout.Write(p.Stdout.Data) //nolint:errcheck
+		case *pb.Frame_Stderr:
+			stderr.Write(p.Stderr.Data) //nolint:errcheck
+		case *pb.Frame_Exit:
+			return int(p.Exit.Code), nil
+		}
+	}
+}
+
+// NineP opens the NineP stream and wraps it as a net.Conn, so it can be
+// handed anywhere a dialed TCP connection to s.port9p would otherwise
+// go.
+func (c *Client) NineP(ctx context.Context) (net.Conn, error) {
+	stream, err := c.c.P9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &nineConn{stream: stream}, nil
+}
+
+// nineConn adapts the P9 stream's framed messages to the net.Conn
+// stream interface that the kernel 9p client (and P9FS's p9.Client)
+// expect.
+type nineConn struct {
+	stream  pb.Session_P9Client
+	readBuf []byte
+}
+
+func (n *nineConn) Read(b []byte) (int, error) {
+	for len(n.readBuf) == 0 {
+		m, err := n.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		n.readBuf = m.Data
+	}
+	c := copy(b, n.readBuf)
+	n.readBuf = n.readBuf[c:]
+	return c, nil
+}
+
+func (n *nineConn) Write(b []byte) (int, error) {
+	if err := n.stream.Send(&pb.NineP{Data: b}); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (n *nineConn) Close() error { return n.stream.CloseSend() }
+
+func (n *nineConn) LocalAddr() net.Addr  { return nineAddr{} }
+func (n *nineConn) RemoteAddr() net.Addr { return nineAddr{} }
+
+func (n *nineConn) SetDeadline(t time.Time) error      { return nil }
+func (n *nineConn) SetReadDeadline(t time.Time) error  { return nil }
+func (n *nineConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// nineAddr is a placeholder net.Addr: the NineP stream rides an
+// existing gRPC connection, which has its own addressing, so there is
+// nothing more specific to report here.
+type nineAddr struct{}
+
+func (nineAddr) Network() string { return "grpc" }
+func (nineAddr) String() string  { return "grpctransport" }
@@ -0,0 +1,234 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grpctransport promotes the bidirectional Exec/NineP streams
+// sketched out in cmds/gcpud/mess into a reusable transport: a cpud can
+// offer it as an alternative to ssh+9p, authenticated the same way cpu
+// already authenticates ssh, by mutual TLS built from the very same
+// ed25519/rsa host and user keys instead of requiring a separate PKI.
+package grpctransport
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// loadPrivateKey reads an ed25519 or RSA private key from a PEM file in
+// one of the formats gliderlabs/ssh.HostKeyFile also reads for these key
+// types: PKCS#8, PKCS#1 (RSA only), or OpenSSH.
+func loadPrivateKey(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:read key %s: %v", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("CPUD:no PEM block in %s", path)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	// ssh returns an OpenSSH-format ed25519 key as a pointer.
+	if key, err := ssh.ParseRawPrivateKey(data); err == nil {
+		if k, ok := key.(*ed25519.PrivateKey); ok {
+			return *k, nil
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("CPUD:%s: unsupported private key format", path)
+}
+
+// selfSignedCert wraps key in a minimal self-signed certificate, purely
+// so it can be handed to crypto/tls: the cpu transport's trust decision
+// is "does the peer hold the private key we already know about" (the
+// same question the ssh PublicKeyHandler asks), not "was this cert
+// issued by a CA", so no real PKI is needed here.
+func selfSignedCert(key interface{}) (tls.Certificate, error) {
+	var pub interface{}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		pub = k.Public()
+	case *rsa.PrivateKey:
+		pub = &k.PublicKey
+	default:
+		return tls.Certificate{}, fmt.Errorf("CPUD:unsupported key type %T", key)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cpu"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * 365 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, pub, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("CPUD:create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// loadAuthorizedKey parses an OpenSSH authorized_keys-format public key
+// file -- the same format server.New's publicKeyFile and a cpu client's
+// known-host-key file already use -- returning the key for comparison
+// against a TLS certificate's public key.
+func loadAuthorizedKey(path string) (ssh.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:read key %s: %v", path, err)
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:parse authorized key %s: %v", path, err)
+	}
+	return key, nil
+}
+
+// verifyPeerKey returns a tls.Config.VerifyPeerCertificate callback that
+// rejects any connection whose peer certificate's public key isn't
+// want. The self-signed certs selfSignedCert builds carry no other
+// trust signal (no CA, no chain) -- the one question that matters,
+// matching ssh's own PublicKeyHandler/KeysEqual, is "does the peer hold
+// the specific key we already know about."
+func verifyPeerKey(want ssh.PublicKey) func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	wantBytes := want.Marshal()
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("CPUD:no peer certificate presented")
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("CPUD:parse peer certificate: %v", err)
+		}
+		got, err := ssh.NewPublicKey(cert.PublicKey)
+		if err != nil {
+			return fmt.Errorf("CPUD:peer certificate key: %v", err)
+		}
+		if subtle.ConstantTimeCompare(got.Marshal(), wantBytes) != 1 {
+			return fmt.Errorf("CPUD:peer certificate key does not match the expected key")
+		}
+		return nil
+	}
+}
+
+// ServerCredentials builds mTLS credentials for a cpud grpctransport
+// server, from the same host key file New(publicKeyFile, hostKeyFile)
+// already loads for ssh. clientPubKeyFile is the authorized_keys-style
+// file naming the one client key this server accepts, the gRPC
+// counterpart of server.New's publicKeyFile; a connecting client
+// presenting any other key, or none, is rejected during the handshake
+// instead of being admitted and left for an RPC handler to check later.
+func ServerCredentials(hostKeyFile, clientPubKeyFile string) (credentials.TransportCredentials, error) {
+	key, err := loadPrivateKey(hostKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := selfSignedCert(key)
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := loadAuthorizedKey(clientPubKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates:          []tls.Certificate{cert},
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: verifyPeerKey(clientKey),
+	}), nil
+}
+
+// AnyClientCredentials builds mTLS credentials for a grpctransport server
+// that, unlike ServerCredentials, doesn't pin one allowed client key: it's
+// for servers such as cpu-broker that are reached by many different
+// clients and decide per-RPC (via PeerFingerprint and their own ACL,
+// rather than at the handshake) which of those clients' keys it trusts
+// for what. The handshake itself still requires every client to present
+// some certificate, so PeerFingerprint always has one to read.
+func AnyClientCredentials(hostKeyFile string) (credentials.TransportCredentials, error) {
+	key, err := loadPrivateKey(hostKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := selfSignedCert(key)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	}), nil
+}
+
+// PeerFingerprint returns the SHA256 fingerprint (in the same
+// "SHA256:<base64>" form ssh-keygen -lf prints) of the TLS certificate
+// the peer on ctx's gRPC connection presented during the AnyClientCredentials
+// handshake. Callers such as cpu-broker's ACL check use this instead of a
+// client-supplied fingerprint field, since that has no cryptographic
+// binding to the connection it arrived on and any client could claim any
+// value.
+func PeerFingerprint(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("CPUD:no peer information on context")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", fmt.Errorf("CPUD:peer connection is not TLS")
+	}
+	certs := tlsInfo.State.PeerCertificates
+	if len(certs) == 0 {
+		return "", fmt.Errorf("CPUD:peer presented no certificate")
+	}
+	key, err := ssh.NewPublicKey(certs[0].PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("CPUD:peer certificate key: %v", err)
+	}
+	return ssh.FingerprintSHA256(key), nil
+}
+
+// ClientCredentials builds mTLS credentials for a cpu client dialing a
+// grpctransport cpud, presenting userKeyFile as its client certificate.
+// serverPubKeyFile names the one host key this client trusts, the
+// gRPC counterpart of an ssh known_hosts entry; a server presenting any
+// other key is rejected during the handshake rather than trusted on
+// sight.
+func ClientCredentials(userKeyFile, serverPubKeyFile string) (credentials.TransportCredentials, error) {
+	key, err := loadPrivateKey(userKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := selfSignedCert(key)
+	if err != nil {
+		return nil, err
+	}
+	serverKey, err := loadAuthorizedKey(serverPubKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates:          []tls.Certificate{cert},
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyPeerKey(serverKey),
+	}), nil
+}
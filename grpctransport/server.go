@@ -0,0 +1,291 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpctransport
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/hugelgupf/p9/p9"
+	pb "github.com/u-root/cpu/cmds/gcpud/mess/cpu"
+)
+
+// Server implements pb.SessionServer: the same Exec/NineP relay
+// cmds/gcpud/mess/s.go prototyped, promoted here so both gcpud and
+// cpud's muxed gRPC listener share one implementation instead of cpud
+// growing its own copy.
+type Server struct {
+	pb.UnimplementedSessionServer
+
+	// NineAddr is the local address of the 9p server this cpud
+	// already listens on for the kernel-9p/FUSE transport; NineP
+	// relays the reverse namespace mount to it. Ignored if Attacher
+	// is set.
+	NineAddr string
+
+	// Attacher, if set, serves the 9p protocol directly against this
+	// backend in-process instead of relaying to NineAddr, so a
+	// single grpctransport connection carries both the Exec session
+	// and the reverse-mounted namespace with no second hop.
+	Attacher p9.Attacher
+}
+
+// NewServer returns a Server relaying NineP traffic to nineAddr.
+func NewServer(nineAddr string) *Server {
+	return &Server{NineAddr: nineAddr}
+}
+
+// NewServerWithAttacher returns a Server that serves 9p directly
+// against attacher (e.g. a *client.CPU9P or *server.UFS), rather than
+// relaying NineP to a separate local 9p listener.
+func NewServerWithAttacher(attacher p9.Attacher) *Server {
+	return &Server{Attacher: attacher}
+}
+
+// Exec implements pb.SessionServer.Exec. If the client's Start frame
+// asks for a tty, the command is run under a pty (resized live by
+// subsequent Resize frames); otherwise it runs with plain pipes, same
+// as before.
+func (s *Server) Exec(stream pb.Session_ExecServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := in.GetStart()
+	if start == nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	c := exec.Command(start.Argv[0], start.Argv[1:]...)
+	c.Env = append(os.Environ(), start.Env...)
+	if start.Cwd != "" {
+		c.Dir = start.Cwd
+	}
+	if start.Tty {
+		return s.execPTY(stream, c, start)
+	}
+	return s.execPipes(stream, c)
+}
+
+// execPipes runs c with plain stdin/stdout/stderr pipes, multiplexing
+// them onto the Exec stream as separate Stdout/Stderr frames.
+func (s *Server) execPipes(stream pb.Session_ExecServer, c *exec.Cmd) error {
+	stdin, err := c.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := c.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := c.Start(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go copyFrames(&wg, stdout, func(b []byte) *pb.Frame {
+		return &pb.Frame{Payload: &pb.Frame_Stdout{Stdout: &pb.Stdout{Data: b}}}
+	}, stream)
+	go copyFrames(&wg, stderr, func(b []byte) *pb.Frame {
+		return &pb.Frame{Payload: &pb.Frame_Stderr{Stderr: &pb.Stderr{Data: b}}}
+	}, stream)
+
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			switch p := in.Payload.(type) {
+			case *pb.Frame_Stdin:
+				if _, err := stdin.Write(p.Stdin.Data); err != nil {
+					return
+				}
+			case *pb.Frame_Signal:
+				if proc := c.Process; proc != nil {
+					proc.Signal(syscall.Signal(p.Signal.Num))
+				}
+			case *pb.Frame_Resize:
+				// No pty in this path, so there's no window
+				// to resize; ignored rather than an error.
+			}
+		}
+	}()
+
+	runErr := c.Wait()
+	stdin.Close()
+	wg.Wait()
+	return stream.Send(&pb.Frame{Payload: &pb.Frame_Exit{Exit: exitFrame(runErr)}})
+}
+
+// execPTY runs c with a pty as its controlling terminal, seeded from
+// start's rows/cols/term and live-resized by Resize frames.
+func (s *Server) execPTY(stream pb.Session_ExecServer, c *exec.Cmd, start *pb.Start) error {
+	if start.Term != "" {
+		c.Env = append(c.Env, "TERM="+start.Term)
+	}
+	f, err := pty.Start(c)
+	if err != nil {
+		return err
+	}
+	if start.Rows != 0 || start.Cols != 0 {
+		pty.Setsize(f, &pty.Winsize{Rows: uint16(start.Rows), Cols: uint16(start.Cols)}) //nolint:errcheck
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go copyFrames(&wg, f, func(b []byte) *pb.Frame {
+		return &pb.Frame{Payload: &pb.Frame_Stdout{Stdout: &pb.Stdout{Data: b}}}
+	}, stream)
+
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			switch p := in.Payload.(type) {
+			case *pb.Frame_Stdin:
+				if _, err := f.Write(p.Stdin.Data); err != nil {
+					return
+				}
+			case *pb.Frame_Signal:
+				if proc := c.Process; proc != nil {
+					proc.Signal(syscall.Signal(p.Signal.Num))
+				}
+			case *pb.Frame_Resize:
+				pty.Setsize(f, &pty.Winsize{Rows: uint16(p.Resize.Rows), Cols: uint16(p.Resize.Cols)}) //nolint:errcheck
+			}
+		}
+	}()
+
+	runErr := c.Wait()
+	f.Close()
+	wg.Wait()
+	return stream.Send(&pb.Frame{Payload: &pb.Frame_Exit{Exit: exitFrame(runErr)}})
+}
+
+// copyFrames copies r in chunks to stream, wrapping each chunk with
+// wrap, until r hits EOF or a Send fails.
+func copyFrames(wg *sync.WaitGroup, r io.Reader, wrap func([]byte) *pb.Frame, stream pb.Session_ExecServer) {
+	defer wg.Done()
+	b := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if serr := stream.Send(wrap(b[:n])); serr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// exitFrame translates an exec.Cmd.Wait error into a pb.Exit.
+func exitFrame(runErr error) *pb.Exit {
+	exit := &pb.Exit{}
+	if runErr != nil {
+		exit.Error = runErr.Error()
+		if ee, ok := runErr.(*exec.ExitError); ok {
+			exit.Code = int32(ee.ExitCode())
+		} else {
+			exit.Code = -1
+		}
+	}
+	return exit
+}
+
+// P9 implements pb.SessionServer.P9.
+func (s *Server) P9(stream pb.Session_P9Server) error {
+	if s.Attacher != nil {
+		sc := &streamConn{stream: stream}
+		return p9.NewServer(s.Attacher).Handle(sc, sc)
+	}
+	return relayNineP(stream, s.NineAddr)
+}
+
+// streamConn adapts the NineP stream to the io.ReadCloser/io.WriteCloser
+// pair a p9.Server wants to Handle, for serving 9p directly over the
+// grpctransport connection instead of relaying to a separate listener.
+type streamConn struct {
+	stream  pb.Session_P9Server
+	readBuf []byte
+}
+
+func (c *streamConn) Read(b []byte) (int, error) {
+	for len(c.readBuf) == 0 {
+		m, err := c.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		c.readBuf = m.Data
+	}
+	n := copy(b, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+	return n, nil
+}
+
+func (c *streamConn) Write(b []byte) (int, error) {
+	if err := c.stream.Send(&pb.NineP{Data: b}); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c *streamConn) Close() error { return nil }
+
+// relayNineP dials addr (cpud's own 9p server) and pumps framed
+// messages between it and the gRPC stream in both directions.
+func relayNineP(stream pb.Session_P9Server, addr string) error {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	errc := make(chan error, 2)
+	go func() {
+		b := make([]byte, 32*1024)
+		for {
+			n, err := conn.Read(b)
+			if n > 0 {
+				if err := stream.Send(&pb.NineP{Data: append([]byte(nil), b[:n]...)}); err != nil {
+					errc <- err
+					return
+				}
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		for {
+			m, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			if _, err := conn.Write(m.Data); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+	return <-errc
+}
@@ -0,0 +1,26 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import "github.com/hugelgupf/p9/p9"
+
+// Backend is what a cpud 9P server needs from whatever it is serving up:
+// a host filesystem, a chroot, an overlayfs snapshot, a container rootfs,
+// or anything else that can answer 9P2000.L requests. CPU9P (in package
+// client) hard-codes one such backend against os.* calls; Backend lets a
+// cpud pick a different one at Attach time instead of being locked into
+// whichever server the caller happened to wire up.
+//
+// A Backend implementation is expected to be stateless between fids: all
+// the state a fid needs (its path, its open *os.File, ...) lives in the
+// p9.File that Attach/Walk/Create/Mkdir return, exactly as CPU9P does it
+// today. Backend exists one level up from p9.File so a single process can
+// serve more than one namespace (e.g. one ufs root per cpud session)
+// without global state.
+type Backend interface {
+	// Attach returns the p9.File for the root of this backend, as seen
+	// by the named user (the 9P "uname" field; "" means unspecified).
+	Attach(uname string) (p9.File, error)
+}
@@ -0,0 +1,72 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/hugelgupf/p9/p9"
+	"github.com/u-root/cpu/session"
+)
+
+// GoferListener wraps a pre-opened fd (the convention runsc uses to
+// hand a gofer process its end of an already-connected 9P2000.L socket,
+// instead of having the gofer dial or listen on its own) as a net.Conn,
+// ready to hand to ServeGoferConn.
+//
+// A cpu server running in -gofer-fd mode passes the fd runsc gave it
+// here, wraps a CPU9P (or any other p9.Attacher, e.g. UFS) as the
+// backend, and otherwise behaves exactly like a normal 9P server; the
+// OCI spec for the sandboxed container then points its root.path at the
+// cpu client's reverse-mounted namespace.
+func GoferListener(fd int) (net.Conn, error) {
+	f := os.NewFile(uintptr(fd), "gofer")
+	conn, err := net.FileConn(f)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:gofer fd %d: %v", fd, err)
+	}
+	return conn, nil
+}
+
+// ServeGoferConn serves 9P2000.L on conn (typically the result of
+// GoferListener) against attacher until conn is closed.
+func ServeGoferConn(conn net.Conn, attacher p9.Attacher) error {
+	return p9.NewServer(attacher).Handle(conn, conn)
+}
+
+// ServeGofer accepts 9P2000.L connections on l (e.g. a Muxer's
+// Match9P sub-listener) and serves each against attacher until l is
+// closed or an Accept fails.
+func ServeGofer(l net.Listener, attacher p9.Attacher) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := serveMuxedGoferConn(conn, attacher); err != nil {
+				v("CPUD:gofer connection: %v", err)
+			}
+		}()
+	}
+}
+
+// serveMuxedGoferConn strips the session.NinePMagic prefix before
+// serving conn. cmux's PrefixMatcher, which routes connections to a
+// Muxer's Match9P sub-listener, only peeks at a connection's leading
+// bytes to decide where it goes; it does not consume them, so those
+// same bytes are still the first thing conn yields. Without stripping
+// them here, the 9P decoder would see NinePMagic prepended to the
+// real first Tversion message and fail to parse it.
+func serveMuxedGoferConn(conn net.Conn, attacher p9.Attacher) error {
+	prefix := make([]byte, len(session.NinePMagic))
+	if _, err := io.ReadFull(conn, prefix); err != nil {
+		return fmt.Errorf("CPUD:gofer: reading NinePMagic prefix: %v", err)
+	}
+	return ServeGoferConn(conn, attacher)
+}
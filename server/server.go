@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
 	"os/exec"
 	"strings"
@@ -19,7 +20,9 @@ import (
 	// It can not, however, unpack password-protected keys yet.
 	"github.com/gliderlabs/ssh"
 	"github.com/hashicorp/go-multierror"
+	"github.com/hugelgupf/p9/p9"
 	"github.com/kr/pty" // TODO: get rid of krpty
+	"github.com/u-root/cpu/client"
 	"github.com/u-root/u-root/pkg/termios"
 	"golang.org/x/sys/unix"
 )
@@ -173,6 +176,11 @@ func (s *Session) Run() error {
 
 	}
 	v("CPUD: bind mounts done")
+	if cleanup, err := s.reverseExport(); err != nil {
+		v("CPUD:reverseExport: %v", err)
+	} else {
+		defer cleanup()
+	}
 	if err := s.Terminal(); err != nil {
 		s.fail = true
 		errors = multierror.Append(err)
@@ -199,7 +207,20 @@ func (s *Session) Run() error {
 	v("CPUD:runRemote: command is %q", s.args)
 	c := exec.Command(s.cmd, s.args...)
 	c.Stdin, c.Stdout, c.Stderr, c.Dir = s.Stdin, s.Stdout, s.Stderr, os.Getenv("PWD")
-	err := c.Run()
+	join, cleanup, err := s.ApplySpec(c)
+	if err != nil {
+		return multierror.Append(errors, err)
+	}
+	defer cleanup()
+	if err := c.Start(); err != nil {
+		return multierror.Append(errors, err)
+	}
+	if join != nil {
+		if err := join(c.Process.Pid); err != nil {
+			v("CPUD:ApplySpec: join cgroup: %v", err)
+		}
+	}
+	err = c.Wait()
 	v("CPUD:Run %v returns %v", c, err)
 	if err != nil {
 		if s.fail && len(wtf) != 0 {
@@ -215,6 +236,48 @@ func (s *Session) Run() error {
 	return err
 }
 
+// reverseExport is the inverse of Namespace: instead of mounting the
+// client's exported files onto this cpud, it exports a subtree of
+// this machine -- /proc, /sys, build output under $PWD, whatever the
+// client asked for -- back to the client over the same SSH
+// connection. It is named the way Namespace's CPU_NAMESPACE/CPUNONCE
+// pair is: CPU_REVERSE_ROOT is the path to export, and CPU_REVERSE_PORT
+// is the TCP port, on this machine's loopback, that a "tcpip-forward"
+// request the client already sent (the ssh -R side of the session,
+// handled by the ForwardedTCPHandler wired into New()) is listening
+// on. Dialing that port, rather than needing any direct access to the
+// SSH channel machinery, is what gets a connection tunnelled back to
+// the client: gliderlabs/ssh turns any accept on it into a
+// forwarded-tcpip channel to the client automatically.
+//
+// If CPU_REVERSE_ROOT is unset, this is a no-op: most sessions do not
+// ask for a reverse export.
+func (s *Session) reverseExport() (func(), error) {
+	root, ok := os.LookupEnv("CPU_REVERSE_ROOT")
+	if !ok || root == "" {
+		return func() {}, nil
+	}
+	port, ok := os.LookupEnv("CPU_REVERSE_PORT")
+	if !ok || port == "" {
+		return func() {}, fmt.Errorf("CPU_REVERSE_ROOT set without CPU_REVERSE_PORT")
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", port))
+	if err != nil {
+		return func() {}, fmt.Errorf("CPUD:dial reverse 9p port %s: %w", port, err)
+	}
+
+	srv := p9.NewServer(client.NewCPU9P(root))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := srv.Handle(conn, conn); err != nil {
+			v("CPUD:reverse 9p export of %s: %v", root, err)
+		}
+	}()
+	return func() { conn.Close(); <-done }, nil
+}
+
 func setWinsize(f *os.File, w, h int) {
 	syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCSWINSZ), //nolint
 		uintptr(unsafe.Pointer(&struct{ h, w, x, y uint16 }{uint16(h), uint16(w), 0, 0})))
@@ -238,6 +301,12 @@ func handler(s ssh.Session) {
 	v("handler: cmd is %v", a)
 	cmd := command(a[0], a[1:]...)
 	cmd.Env = append(cmd.Env, s.Environ()...)
+	if sock, cleanup, err := forwardAgent(s); err == nil && sock != "" {
+		cmd.Env = append(cmd.Env, "SSH_AUTH_SOCK="+sock)
+		defer cleanup()
+	} else if err != nil {
+		v("CPUD:agent forwarding: %v", err)
+	}
 	ptyReq, winCh, isPty := s.Pty()
 	if isPty {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("TERM=%s", ptyReq.Term))
@@ -288,6 +357,24 @@ func handler(s ssh.Session) {
 	verbose("handler exits")
 }
 
+// forwardAgent, if the client requested agent forwarding for this
+// session, opens a local listener that relays to the client's agent
+// over s and returns its address for SSH_AUTH_SOCK. The returned
+// cleanup must be deferred by the caller to close the listener once
+// the session's command has exited. sock is "" (with a nil error) if
+// the client didn't ask for agent forwarding.
+func forwardAgent(s ssh.Session) (sock string, cleanup func(), err error) {
+	if !ssh.AgentRequested(s) {
+		return "", func() {}, nil
+	}
+	l, err := ssh.NewAgentListener()
+	if err != nil {
+		return "", nil, fmt.Errorf("CPUD:NewAgentListener: %v", err)
+	}
+	go ssh.ForwardAgentConnections(l, s)
+	return l.Addr().String(), func() { l.Close() }, nil
+}
+
 // NewSession returns a New session with defaults set.
 // TODO: should session be a separate package.
 func NewSession(port9p, cmd string, args ...string) *Session {
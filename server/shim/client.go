@@ -0,0 +1,163 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shim
+
+import (
+	"context"
+	"io"
+
+	pb "github.com/u-root/cpu/server/shim/shimpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Client is a cpu client's handle on a cpud's shim control plane: it
+// can create, start, and observe any number of Sessions over one
+// connection instead of opening a new SSH channel per command.
+type Client struct {
+	conn *grpc.ClientConn
+	c    pb.ShimClient
+}
+
+// Dial connects to a cpud's shim listener at addr.
+func Dial(addr string, creds credentials.TransportCredentials) (*Client, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, c: pb.NewShimClient(conn)}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Create allocates a Session with the given ID and argv/env/cwd, but
+// does not start it.
+func (c *Client) Create(ctx context.Context, id string, argv, env []string, cwd string, terminal bool) error {
+	_, err := c.c.Create(ctx, &pb.CreateRequest{Id: id, Argv: argv, Env: env, Cwd: cwd, Terminal: terminal})
+	return err
+}
+
+// Exec registers a process to run inside an existing Session's mount
+// namespace. Call Start with the same execID to run it.
+func (c *Client) Exec(ctx context.Context, id, execID string, argv, env []string, cwd string, terminal bool) error {
+	_, err := c.c.Exec(ctx, &pb.ExecRequest{Id: id, ExecId: execID, Argv: argv, Env: env, Cwd: cwd, Terminal: terminal})
+	return err
+}
+
+// Start runs a Session's init process (execID "") or a previously
+// Exec'd process, and returns its pid.
+func (c *Client) Start(ctx context.Context, id, execID string) (int, error) {
+	resp, err := c.c.Start(ctx, &pb.StartRequest{Id: id, ExecId: execID})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Pid), nil
+}
+
+// State reports a Session or Exec's current status, pid, and exit
+// status (the latter only meaningful once status is "stopped").
+func (c *Client) State(ctx context.Context, id, execID string) (status string, pid int, exitStatus int, err error) {
+	resp, err := c.c.State(ctx, &pb.StateRequest{Id: id, ExecId: execID})
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return resp.Status, int(resp.Pid), int(resp.ExitStatus), nil
+}
+
+// Kill sends signal to a Session's init process or one of its Execs.
+func (c *Client) Kill(ctx context.Context, id, execID string, signal int) error {
+	_, err := c.c.Kill(ctx, &pb.KillRequest{Id: id, ExecId: execID, Signal: int32(signal)})
+	return err
+}
+
+// ResizePty delivers a window-size change to a Session or Exec's pty.
+func (c *Client) ResizePty(ctx context.Context, id, execID string, rows, cols int) error {
+	_, err := c.c.ResizePty(ctx, &pb.ResizePtyRequest{Id: id, ExecId: execID, Rows: uint32(rows), Cols: uint32(cols)})
+	return err
+}
+
+// CloseIO closes a Session or Exec's stdin.
+func (c *Client) CloseIO(ctx context.Context, id, execID string) error {
+	_, err := c.c.CloseIO(ctx, &pb.CloseIORequest{Id: id, ExecId: execID})
+	return err
+}
+
+// Delete releases a Session or Exec's resources once it has exited,
+// returning its final pid and exit status.
+func (c *Client) Delete(ctx context.Context, id, execID string) (pid int, exitStatus int, err error) {
+	resp, err := c.c.Delete(ctx, &pb.DeleteRequest{Id: id, ExecId: execID})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(resp.Pid), int(resp.ExitStatus), nil
+}
+
+// Events streams lifecycle events for every Session and Exec on the
+// connected cpud until ctx is done.
+func (c *Client) Events(ctx context.Context) (<-chan *pb.Event, error) {
+	stream, err := c.c.Events(ctx, &pb.EventsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *pb.Event, 16)
+	go func() {
+		defer close(ch)
+		for {
+			e, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			ch <- e
+		}
+	}()
+	return ch, nil
+}
+
+// Attach opens the Pty stream for id/execID and wires stdin/stdout/
+// stderr to it, returning once the stream ends (normally when the
+// process exits and the server closes it).
+func (c *Client) Attach(ctx context.Context, id, execID string, stdin io.Reader, stdout, stderr io.Writer) error {
+	stream, err := c.c.Pty(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&pb.PtyFrame{Payload: &pb.PtyFrame_Attach{Attach: &pb.PtyAttach{Id: id, ExecId: execID}}}); err != nil {
+		return err
+	}
+
+	go func() {
+		b := make([]byte, 32*1024)
+		for {
+			n, err := stdin.Read(b)
+			if n > 0 {
+				if serr := stream.Send(&pb.PtyFrame{Payload: &pb.PtyFrame_Stdin{Stdin: &pb.PtyData{Data: append([]byte(nil), b[:n]...)}}}); serr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		switch p := in.Payload.(type) {
+		case *pb.PtyFrame_Stdout:
+			stdout.Write(p.Stdout.Data) //nolint:errcheck
+		case *pb.PtyFrame_Stderr:
+			stderr.Write(p.Stderr.Data) //nolint:errcheck
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"golang.org/x/sys/unix"
+)
+
+// nsenterPIDEnv and nsenterArgvEnv are how newNsenterCmd asks the
+// re-executed copy of this binary to join an existing session's mount
+// namespace before running the real command, the same way
+// server.ApplySpec passes CPU_OCI_SPEC through the environment rather
+// than flags.
+const (
+	nsenterPIDEnv  = "CPU_SHIM_NSENTER_PID"
+	nsenterArgvEnv = "CPU_SHIM_NSENTER_ARGV"
+)
+
+// init runs before anything else in this process -- including
+// whatever main the binary importing this package has -- which makes
+// it the only place a pure Go program can join a namespace before
+// exec without shelling out to a separate nsenter(1) binary: if it
+// notices it was re-executed to join a session's mount namespace, it
+// does so and execs the real command in its place, and never returns
+// to let the binary's usual startup run. Every other process never
+// sets these variables and falls through unchanged.
+func init() {
+	pidStr, ok := os.LookupEnv(nsenterPIDEnv)
+	if !ok {
+		return
+	}
+	if err := nsenterAndExec(pidStr); err != nil {
+		fmt.Fprintf(os.Stderr, "shim: nsenter: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func nsenterAndExec(pidStr string) error {
+	ns, err := os.Open(fmt.Sprintf("/proc/%s/ns/mnt", pidStr))
+	if err != nil {
+		return fmt.Errorf("open mnt namespace of %s: %w", pidStr, err)
+	}
+	defer ns.Close()
+	if err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWNS); err != nil {
+		return fmt.Errorf("setns: %w", err)
+	}
+
+	var argv []string
+	if err := json.Unmarshal([]byte(os.Getenv(nsenterArgvEnv)), &argv); err != nil {
+		return fmt.Errorf("bad %s: %w", nsenterArgvEnv, err)
+	}
+	if len(argv) == 0 {
+		return fmt.Errorf("%s: empty argv", nsenterArgvEnv)
+	}
+
+	os.Unsetenv(nsenterPIDEnv)
+	os.Unsetenv(nsenterArgvEnv)
+
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		return err
+	}
+	return unix.Exec(path, argv, os.Environ())
+}
@@ -0,0 +1,476 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package shim implements a containerd-shim-style gRPC control plane
+// for cpud sessions: Create/Start/Delete manage a Session's init
+// process, Exec adds further processes sharing its mount namespace,
+// and Pty/Events/Kill/ResizePty/CloseIO drive and observe them, all
+// addressed by a stable ID instead of the SSH channel that created
+// them.
+package shim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/creack/pty"
+	pb "github.com/u-root/cpu/server/shim/shimpb"
+)
+
+// proc is one process a Server is tracking: a Session's init process
+// (execID "") or one of its Execs.
+type proc struct {
+	id     string
+	execID string
+	argv   []string
+	env    []string
+	cwd    string
+	term   bool
+
+	mu         sync.Mutex
+	cmd        *exec.Cmd
+	pty        *os.File
+	stdin      io.WriteCloser
+	stdout     io.ReadCloser
+	stderr     io.ReadCloser
+	status     string // "created", "running", "stopped"
+	pid        int
+	exitStatus int32
+	exited     chan struct{}
+}
+
+// session is a Session's init process plus the Execs running inside
+// its mount namespace.
+type session struct {
+	mu    sync.Mutex
+	init  *proc
+	execs map[string]*proc
+}
+
+// Server implements pb.ShimServer.
+type Server struct {
+	pb.UnimplementedShimServer
+
+	mu       sync.Mutex
+	sessions map[string]*session
+
+	subsMu sync.Mutex
+	subs   map[chan *pb.Event]struct{}
+}
+
+// NewServer returns a Server, properly initialized.
+func NewServer() *Server {
+	return &Server{
+		sessions: map[string]*session{},
+		subs:     map[chan *pb.Event]struct{}{},
+	}
+}
+
+func (s *Server) emit(e *pb.Event) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- e:
+		default:
+			// A slow subscriber drops events rather than stalling
+			// the session whose lifecycle everyone else still
+			// wants to hear about.
+		}
+	}
+}
+
+func (s *Server) getSession(id string) (*session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session %q: not found", id)
+	}
+	return sess, nil
+}
+
+// getProc resolves id/execID to a tracked proc: execID "" is a
+// Session's init process, anything else is one of its Execs.
+func (s *Server) getProc(id, execID string) (*proc, error) {
+	sess, err := s.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if execID == "" {
+		return sess.init, nil
+	}
+	p, ok := sess.execs[execID]
+	if !ok {
+		return nil, fmt.Errorf("session %q: exec %q: not found", id, execID)
+	}
+	return p, nil
+}
+
+// Create implements pb.ShimServer.Create.
+func (s *Server) Create(ctx context.Context, req *pb.CreateRequest) (*pb.CreateResponse, error) {
+	if req.Id == "" {
+		return nil, errors.New("shim: Create: empty id")
+	}
+	if len(req.Argv) == 0 {
+		return nil, errors.New("shim: Create: empty argv")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sessions[req.Id]; ok {
+		return nil, fmt.Errorf("shim: Create: session %q already exists", req.Id)
+	}
+
+	p := &proc{
+		id:     req.Id,
+		argv:   req.Argv,
+		env:    req.Env,
+		cwd:    req.Cwd,
+		term:   req.Terminal,
+		status: "created",
+		exited: make(chan struct{}),
+	}
+	s.sessions[req.Id] = &session{init: p, execs: map[string]*proc{}}
+	return &pb.CreateResponse{Id: req.Id}, nil
+}
+
+// Exec implements pb.ShimServer.Exec. The new process is only
+// registered here; Start actually runs it, joined to the Session's
+// mount namespace via the nsenter helper in nsenter_linux.go.
+func (s *Server) Exec(ctx context.Context, req *pb.ExecRequest) (*pb.ExecResponse, error) {
+	if req.ExecId == "" {
+		return nil, errors.New("shim: Exec: empty exec_id")
+	}
+	if len(req.Argv) == 0 {
+		return nil, errors.New("shim: Exec: empty argv")
+	}
+	sess, err := s.getSession(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if _, ok := sess.execs[req.ExecId]; ok {
+		return nil, fmt.Errorf("shim: Exec: exec %q already exists", req.ExecId)
+	}
+	sess.execs[req.ExecId] = &proc{
+		id:     req.Id,
+		execID: req.ExecId,
+		argv:   req.Argv,
+		env:    req.Env,
+		cwd:    req.Cwd,
+		term:   req.Terminal,
+		status: "created",
+		exited: make(chan struct{}),
+	}
+	return &pb.ExecResponse{ExecId: req.ExecId}, nil
+}
+
+// Start implements pb.ShimServer.Start.
+func (s *Server) Start(ctx context.Context, req *pb.StartRequest) (*pb.StartResponse, error) {
+	p, err := s.getProc(req.Id, req.ExecId)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status != "created" {
+		return nil, fmt.Errorf("shim: Start: %s/%s: already %s", req.Id, req.ExecId, p.status)
+	}
+
+	var c *exec.Cmd
+	if req.ExecId == "" {
+		// The init process: give it its own mount namespace so
+		// later Execs have something to join.
+		c = newSessionCmd(p.argv[0], p.argv[1:]...)
+	} else {
+		init, err := s.getProc(req.Id, "")
+		if err != nil {
+			return nil, err
+		}
+		init.mu.Lock()
+		initPid := init.pid
+		init.mu.Unlock()
+		if initPid == 0 {
+			return nil, fmt.Errorf("shim: Start: %s: init process not started", req.Id)
+		}
+		c, err = newNsenterCmd(initPid, p.argv)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.Env = append(c.Env, p.env...)
+	if p.cwd != "" {
+		c.Dir = p.cwd
+	}
+
+	if p.term {
+		f, err := pty.Start(c)
+		if err != nil {
+			return nil, err
+		}
+		p.pty = f
+	} else {
+		if p.stdin, err = c.StdinPipe(); err != nil {
+			return nil, err
+		}
+		if p.stdout, err = c.StdoutPipe(); err != nil {
+			return nil, err
+		}
+		if p.stderr, err = c.StderrPipe(); err != nil {
+			return nil, err
+		}
+		if err := c.Start(); err != nil {
+			return nil, err
+		}
+	}
+	p.cmd = c
+	p.pid = c.Process.Pid
+	p.status = "running"
+
+	go s.wait(p)
+
+	s.emit(&pb.Event{Id: req.Id, ExecId: req.ExecId, Type: "start"})
+	return &pb.StartResponse{Pid: uint32(p.pid)}, nil
+}
+
+// wait reaps p's process once it exits, records its exit status, and
+// notifies Events subscribers.
+func (s *Server) wait(p *proc) {
+	err := p.cmd.Wait()
+
+	p.mu.Lock()
+	p.status = "stopped"
+	if ee, ok := err.(*exec.ExitError); ok {
+		p.exitStatus = int32(ee.ExitCode())
+	} else if err != nil {
+		p.exitStatus = -1
+	}
+	close(p.exited)
+	p.mu.Unlock()
+
+	s.emit(&pb.Event{Id: p.id, ExecId: p.execID, Type: "exit", ExitStatus: p.exitStatus})
+}
+
+// State implements pb.ShimServer.State.
+func (s *Server) State(ctx context.Context, req *pb.StateRequest) (*pb.StateResponse, error) {
+	p, err := s.getProc(req.Id, req.ExecId)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &pb.StateResponse{Status: p.status, Pid: uint32(p.pid), ExitStatus: p.exitStatus}, nil
+}
+
+// Kill implements pb.ShimServer.Kill.
+func (s *Server) Kill(ctx context.Context, req *pb.KillRequest) (*pb.KillResponse, error) {
+	p, err := s.getProc(req.Id, req.ExecId)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	proc := p.cmd
+	p.mu.Unlock()
+	if proc == nil || proc.Process == nil {
+		return nil, fmt.Errorf("shim: Kill: %s/%s: not started", req.Id, req.ExecId)
+	}
+	if err := proc.Process.Signal(syscall.Signal(req.Signal)); err != nil {
+		return nil, err
+	}
+	return &pb.KillResponse{}, nil
+}
+
+// ResizePty implements pb.ShimServer.ResizePty.
+func (s *Server) ResizePty(ctx context.Context, req *pb.ResizePtyRequest) (*pb.ResizePtyResponse, error) {
+	p, err := s.getProc(req.Id, req.ExecId)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pty == nil {
+		return nil, fmt.Errorf("shim: ResizePty: %s/%s: no pty", req.Id, req.ExecId)
+	}
+	if err := pty.Setsize(p.pty, &pty.Winsize{Rows: uint16(req.Rows), Cols: uint16(req.Cols)}); err != nil {
+		return nil, err
+	}
+	return &pb.ResizePtyResponse{}, nil
+}
+
+// CloseIO implements pb.ShimServer.CloseIO.
+func (s *Server) CloseIO(ctx context.Context, req *pb.CloseIORequest) (*pb.CloseIOResponse, error) {
+	p, err := s.getProc(req.Id, req.ExecId)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch {
+	case p.pty != nil:
+		// A pty has one fd for both directions; closing it here
+		// would also kill stdout/stderr, so there is nothing
+		// narrower CloseIO can do.
+		return nil, errors.New("shim: CloseIO: not supported on a pty session")
+	case p.stdin != nil:
+		if err := p.stdin.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.CloseIOResponse{}, nil
+}
+
+// Delete implements pb.ShimServer.Delete.
+func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	p, err := s.getProc(req.Id, req.ExecId)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	status := p.status
+	pid := p.pid
+	exitStatus := p.exitStatus
+	p.mu.Unlock()
+	if status == "running" {
+		return nil, fmt.Errorf("shim: Delete: %s/%s: still running", req.Id, req.ExecId)
+	}
+
+	if req.ExecId == "" {
+		s.mu.Lock()
+		delete(s.sessions, req.Id)
+		s.mu.Unlock()
+	} else {
+		sess, err := s.getSession(req.Id)
+		if err != nil {
+			return nil, err
+		}
+		sess.mu.Lock()
+		delete(sess.execs, req.ExecId)
+		sess.mu.Unlock()
+	}
+	return &pb.DeleteResponse{Pid: uint32(pid), ExitStatus: exitStatus}, nil
+}
+
+// Events implements pb.ShimServer.Events.
+func (s *Server) Events(req *pb.EventsRequest, stream pb.Shim_EventsServer) error {
+	ch := make(chan *pb.Event, 16)
+	s.subsMu.Lock()
+	s.subs[ch] = struct{}{}
+	s.subsMu.Unlock()
+	defer func() {
+		s.subsMu.Lock()
+		delete(s.subs, ch)
+		s.subsMu.Unlock()
+	}()
+
+	for {
+		select {
+		case e := <-ch:
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// Pty implements pb.ShimServer.Pty: the client's first frame selects
+// the process via Attach, and frames flow both ways from then on. Pty
+// returns, ending the stream, once the process's output has been
+// copied out, which is when the process exits.
+func (s *Server) Pty(stream pb.Shim_PtyServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	attach := in.GetAttach()
+	if attach == nil {
+		return io.ErrUnexpectedEOF
+	}
+	p, err := s.getProc(attach.Id, attach.ExecId)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	f, stdin, stdout, stderr := p.pty, p.stdin, p.stdout, p.stderr
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	if f != nil {
+		wg.Add(1)
+		go copyPtyFrames(&wg, f, func(b []byte) *pb.PtyFrame {
+			return &pb.PtyFrame{Payload: &pb.PtyFrame_Stdout{Stdout: &pb.PtyData{Data: b}}}
+		}, stream)
+	} else {
+		wg.Add(2)
+		go copyPtyFrames(&wg, stdout, func(b []byte) *pb.PtyFrame {
+			return &pb.PtyFrame{Payload: &pb.PtyFrame_Stdout{Stdout: &pb.PtyData{Data: b}}}
+		}, stream)
+		go copyPtyFrames(&wg, stderr, func(b []byte) *pb.PtyFrame {
+			return &pb.PtyFrame{Payload: &pb.PtyFrame_Stderr{Stderr: &pb.PtyData{Data: b}}}
+		}, stream)
+	}
+
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			switch payload := in.Payload.(type) {
+			case *pb.PtyFrame_Stdin:
+				w := stdin
+				if f != nil {
+					w = f
+				}
+				if w != nil {
+					if _, err := w.Write(payload.Stdin.Data); err != nil {
+						break
+					}
+				}
+			case *pb.PtyFrame_Resize:
+				if f != nil {
+					pty.Setsize(f, &pty.Winsize{Rows: uint16(payload.Resize.Rows), Cols: uint16(payload.Resize.Cols)}) //nolint:errcheck
+				}
+			}
+		}
+	}()
+
+	wg.Wait()
+	return nil
+}
+
+// copyPtyFrames copies r in chunks to stream, wrapping each chunk
+// with wrap, until r hits EOF or a Send fails.
+func copyPtyFrames(wg *sync.WaitGroup, r io.Reader, wrap func([]byte) *pb.PtyFrame, stream pb.Shim_PtyServer) {
+	defer wg.Done()
+	if r == nil {
+		return
+	}
+	b := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if serr := stream.Send(wrap(append([]byte(nil), b[:n]...))); serr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// newSessionCmd builds the command for a Session's init process. It
+// unshares a private mount namespace, the same way server.command()
+// does for an SSH-driven session, so that a later Exec has a
+// namespace it can join with nsenter.
+func newSessionCmd(n string, args ...string) *exec.Cmd {
+	c := exec.Command(n, args...)
+	c.SysProcAttr = &syscall.SysProcAttr{Cloneflags: syscall.CLONE_NEWNS}
+	return c
+}
+
+// newNsenterCmd builds the command for an Exec process that must run
+// inside initPid's mount namespace. Go gives no pre-exec hook to run
+// setns(2) between this process's fork and exec, so instead this
+// re-execs the running binary with CPU_SHIM_NSENTER_PID/ARGV set;
+// this package's init in nsenter_linux.go notices those and does the
+// setns-then-exec itself before any of this binary's normal startup
+// runs.
+func newNsenterCmd(initPid int, argv []string) (*exec.Cmd, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("shim: nsenter: %w", err)
+	}
+	encodedArgv, err := json.Marshal(argv)
+	if err != nil {
+		return nil, fmt.Errorf("shim: nsenter: %w", err)
+	}
+	c := exec.Command(self)
+	c.Env = append(os.Environ(),
+		fmt.Sprintf("%s=%d", nsenterPIDEnv, initPid),
+		fmt.Sprintf("%s=%s", nsenterArgvEnv, encodedArgv),
+	)
+	return c, nil
+}
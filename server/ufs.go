@@ -0,0 +1,405 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/hugelgupf/p9/p9"
+	"golang.org/x/sys/unix"
+)
+
+// UFS is a Backend that maps 9P fids directly onto a host directory
+// tree, in the style of go-p9p's ufs session: every fid is just a path
+// plus an optional open *os.File, and Walk/Create/Mkdir/... are thin
+// wrappers around the matching os.* or unix.* call. It is the backend
+// cpud uses when there's no reason to be fancier: a chroot, an overlayfs
+// snapshot, a container rootfs, or just "/".
+type UFS struct {
+	p9.DefaultWalkGetAttr
+
+	root string
+	path string
+	file *os.File
+
+	// refs is shared by every fid Walk derives from one Attach: Walk
+	// increments it and Close decrements it. Close closes the fid's own
+	// file whatever the count, and Remove does not read it; the host
+	// filesystem does the real reference counting for us via the open
+	// file descriptor.
+	refs *int32
+}
+
+// NewUFS returns a Backend rooted at root.
+func NewUFS(root string) *UFS {
+	return &UFS{root: root, path: root}
+}
+
+var (
+	_ p9.File     = &UFS{}
+	_ p9.Attacher = &UFS{}
+	_ Backend     = &UFS{}
+)
+
+// Attach implements Backend.Attach and p9.Attacher.Attach.
+func (u *UFS) Attach(uname string) (p9.File, error) {
+	refs := new(int32)
+	*refs = 1
+	return &UFS{root: u.root, path: u.root, refs: refs}, nil
+}
+
+func (u *UFS) info() (p9.QID, os.FileInfo, error) {
+	var qid p9.QID
+	fi, err := os.Lstat(u.path)
+	if err != nil {
+		return qid, nil, err
+	}
+	qid.Type = p9.ModeFromOS(fi.Mode()).QIDType()
+	qid.Path = fi.Sys().(*syscall.Stat_t).Ino
+	return qid, fi, nil
+}
+
+// Walk implements p9.File.Walk.
+func (u *UFS) Walk(names []string) ([]p9.QID, p9.File, error) {
+	if u.refs != nil {
+		atomic.AddInt32(u.refs, 1)
+	}
+	var qids []p9.QID
+	next := &UFS{root: u.root, path: u.path, refs: u.refs}
+	for _, name := range names {
+		next = &UFS{root: u.root, path: filepath.Join(next.path, name), refs: u.refs}
+		qid, _, err := next.info()
+		if err != nil {
+			return nil, nil, err
+		}
+		qids = append(qids, qid)
+	}
+	if len(names) == 0 {
+		qid, _, err := next.info()
+		if err != nil {
+			return nil, nil, err
+		}
+		qids = append(qids, qid)
+	}
+	return qids, next, nil
+}
+
+// Open implements p9.File.Open.
+func (u *UFS) Open(mode p9.OpenFlags) (p9.QID, uint32, error) {
+	qid, fi, err := u.info()
+	if err != nil {
+		return qid, 0, err
+	}
+	f, err := os.OpenFile(u.path, osflags(fi, mode), 0)
+	if err != nil {
+		return qid, 0, err
+	}
+	u.file = f
+	return qid, 0, nil
+}
+
+// ReadAt implements p9.File.ReadAt.
+func (u *UFS) ReadAt(p []byte, offset int64) (int, error) {
+	return u.file.ReadAt(p, offset)
+}
+
+// WriteAt implements p9.File.WriteAt.
+func (u *UFS) WriteAt(p []byte, offset int64) (int, error) {
+	return u.file.WriteAt(p, offset)
+}
+
+// FSync implements p9.File.FSync.
+func (u *UFS) FSync() error {
+	if u.file == nil {
+		return nil
+	}
+	return u.file.Sync()
+}
+
+// Close implements p9.File.Close.
+func (u *UFS) Close() error {
+	if u.refs != nil && atomic.AddInt32(u.refs, -1) > 0 {
+		if u.file != nil {
+			return u.file.Close()
+		}
+		return nil
+	}
+	if u.file != nil {
+		return u.file.Close()
+	}
+	return nil
+}
+
+// Create implements p9.File.Create.
+func (u *UFS) Create(name string, mode p9.OpenFlags, perm p9.FileMode, _ p9.UID, _ p9.GID) (p9.File, p9.QID, uint32, error) {
+	p := filepath.Join(u.path, name)
+	f, err := os.OpenFile(p, os.O_CREATE|mode.OSFlags(), os.FileMode(perm))
+	if err != nil {
+		return nil, p9.QID{}, 0, err
+	}
+	n := &UFS{root: u.root, path: p, file: f, refs: u.refs}
+	qid, _, err := n.info()
+	if err != nil {
+		f.Close()
+		return nil, p9.QID{}, 0, err
+	}
+	return n, qid, 0, nil
+}
+
+// Mkdir implements p9.File.Mkdir.
+func (u *UFS) Mkdir(name string, perm p9.FileMode, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	p := filepath.Join(u.path, name)
+	if err := os.Mkdir(p, os.FileMode(perm)); err != nil {
+		return p9.QID{}, err
+	}
+	n := &UFS{root: u.root, path: p}
+	return n.info2QID()
+}
+
+func (u *UFS) info2QID() (p9.QID, error) {
+	qid, _, err := u.info()
+	return qid, err
+}
+
+// Symlink implements p9.File.Symlink.
+func (u *UFS) Symlink(oldname, newname string, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	p := filepath.Join(u.path, newname)
+	if err := os.Symlink(oldname, p); err != nil {
+		return p9.QID{}, err
+	}
+	return (&UFS{root: u.root, path: p}).info2QID()
+}
+
+// Link implements p9.File.Link.
+func (u *UFS) Link(target p9.File, newname string) error {
+	t, ok := target.(*UFS)
+	if !ok {
+		return os.ErrInvalid
+	}
+	return os.Link(t.path, filepath.Join(u.path, newname))
+}
+
+// Mknod implements p9.File.Mknod.
+func (u *UFS) Mknod(name string, mode p9.FileMode, major, minor uint32, _ p9.UID, _ p9.GID) (p9.QID, error) {
+	p := filepath.Join(u.path, name)
+	dev := int(unix.Mkdev(major, minor))
+	if err := unix.Mknod(p, uint32(mode), dev); err != nil {
+		return p9.QID{}, err
+	}
+	return (&UFS{root: u.root, path: p}).info2QID()
+}
+
+// Rename implements p9.File.Rename.
+func (u *UFS) Rename(directory p9.File, name string) error {
+	d, ok := directory.(*UFS)
+	if !ok {
+		return os.ErrInvalid
+	}
+	return os.Rename(u.path, filepath.Join(d.path, name))
+}
+
+// RenameAt implements p9.File.RenameAt.
+func (u *UFS) RenameAt(oldName string, newDir p9.File, newName string) error {
+	d, ok := newDir.(*UFS)
+	if !ok {
+		return os.ErrInvalid
+	}
+	return os.Rename(filepath.Join(u.path, oldName), filepath.Join(d.path, newName))
+}
+
+// Remove implements p9.File.Remove.
+func (u *UFS) Remove() error {
+	return os.Remove(u.path)
+}
+
+// UnlinkAt implements p9.File.UnlinkAt.
+func (u *UFS) UnlinkAt(name string, flags uint32) error {
+	return os.Remove(filepath.Join(u.path, name))
+}
+
+// Readdir implements p9.File.Readdir.
+func (u *UFS) Readdir(offset uint64, count uint32) (p9.Dirents, error) {
+	fis, err := os.ReadDir(u.path)
+	if err != nil {
+		return nil, err
+	}
+	var dirents p9.Dirents
+	for i := int(offset); i < len(fis); i++ {
+		e := &UFS{root: u.root, path: filepath.Join(u.path, fis[i].Name())}
+		qid, _, err := e.info()
+		if err != nil {
+			continue
+		}
+		dirents = append(dirents, p9.Dirent{
+			QID:    qid,
+			Type:   qid.Type,
+			Name:   fis[i].Name(),
+			Offset: uint64(i + 1),
+		})
+	}
+	return dirents, nil
+}
+
+// Readlink implements p9.File.Readlink.
+func (u *UFS) Readlink() (string, error) {
+	return os.Readlink(u.path)
+}
+
+// Flush implements p9.File.Flush.
+func (u *UFS) Flush() error {
+	return nil
+}
+
+// Renamed implements p9.File.Renamed.
+func (u *UFS) Renamed(parent p9.File, newName string) {
+	if p, ok := parent.(*UFS); ok {
+		u.path = filepath.Join(p.path, newName)
+	}
+}
+
+// StatFS implements p9.File.StatFS.
+func (u *UFS) StatFS() (p9.FSStat, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(u.path, &st); err != nil {
+		return p9.FSStat{}, err
+	}
+	return p9.FSStat{
+		Type:            uint32(st.Type),
+		BlockSize:       uint32(st.Bsize),
+		Blocks:          st.Blocks,
+		BlocksFree:      st.Bfree,
+		BlocksAvailable: st.Bavail,
+		Files:           st.Files,
+		FilesFree:       st.Ffree,
+		FSID:            uint64(st.Fsid.X__val[0])<<32 | uint64(uint32(st.Fsid.X__val[1])),
+		NameLength:      uint32(st.Namelen),
+	}, nil
+}
+
+// Lock implements p9.File.Lock: a plain fcntl(F_OFD_SETLK) against the
+// already-open fid, which is enough for advisory record locking (flock,
+// dpkg/git's use of open+lock, ...) without a separate lock-table.
+func (u *UFS) Lock(pid int, locktype p9.LockType, flags p9.LockFlags, start, length uint64, client string) (p9.LockStatus, error) {
+	if u.file == nil {
+		return p9.LockStatusError, os.ErrInvalid
+	}
+	how := unix.F_OFD_SETLK
+	if flags&p9.LockFlagsBlock != 0 {
+		how = unix.F_OFD_SETLKW
+	}
+	lk := unix.Flock_t{
+		Type:   lockTypeToUnix(locktype),
+		Whence: int16(os.SEEK_SET),
+		Start:  int64(start),
+		Len:    int64(length),
+	}
+	if err := unix.FcntlFlock(u.file.Fd(), how, &lk); err != nil {
+		if err == unix.EAGAIN {
+			return p9.LockStatusBlocked, nil
+		}
+		return p9.LockStatusError, err
+	}
+	return p9.LockStatusOK, nil
+}
+
+// Getlock implements p9.File.Getlock.
+func (u *UFS) Getlock(pid int, locktype p9.LockType, start, length uint64, client string) (p9.LockType, uint64, uint64, int, string, error) {
+	if u.file == nil {
+		return locktype, start, length, pid, client, os.ErrInvalid
+	}
+	lk := unix.Flock_t{
+		Type:   lockTypeToUnix(locktype),
+		Whence: int16(os.SEEK_SET),
+		Start:  int64(start),
+		Len:    int64(length),
+	}
+	if err := unix.FcntlFlock(u.file.Fd(), unix.F_OFD_GETLK, &lk); err != nil {
+		return locktype, start, length, pid, client, err
+	}
+	return unixToLockType(lk.Type), uint64(lk.Start), uint64(lk.Len), int(lk.Pid), client, nil
+}
+
+func lockTypeToUnix(t p9.LockType) int16 {
+	switch t {
+	case p9.ReadLock:
+		return unix.F_RDLCK
+	case p9.WriteLock:
+		return unix.F_WRLCK
+	default:
+		return unix.F_UNLCK
+	}
+}
+
+func unixToLockType(t int16) p9.LockType {
+	switch t {
+	case unix.F_RDLCK:
+		return p9.ReadLock
+	case unix.F_WRLCK:
+		return p9.WriteLock
+	default:
+		return p9.Unlock
+	}
+}
+
+// GetXattr implements the 9P2000.L xattr extension via Lgetxattr.
+func (u *UFS) GetXattr(name string) ([]byte, error) {
+	buf := make([]byte, 4096)
+	n, err := unix.Lgetxattr(u.path, name, buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// SetXattr implements the 9P2000.L xattr extension via Lsetxattr.
+func (u *UFS) SetXattr(name string, value []byte, flags uint32) error {
+	return unix.Lsetxattr(u.path, name, value, int(flags))
+}
+
+// ListXattr implements the 9P2000.L xattr extension via Llistxattr.
+func (u *UFS) ListXattr() ([]string, error) {
+	buf := make([]byte, 4096)
+	n, err := unix.Llistxattr(u.path, buf)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, s := range splitNUL(buf[:n]) {
+		if s != "" {
+			names = append(names, s)
+		}
+	}
+	return names, nil
+}
+
+// RemoveXattr implements the 9P2000.L xattr extension via Lremovexattr.
+func (u *UFS) RemoveXattr(name string) error {
+	return unix.Lremovexattr(u.path, name)
+}
+
+func splitNUL(b []byte) []string {
+	var out []string
+	start := 0
+	for i, c := range b {
+		if c == 0 {
+			out = append(out, string(b[start:i]))
+			start = i + 1
+		}
+	}
+	return out
+}
+
+// osflags mirrors client.CPU9P's translation of a p9.OpenFlags into the
+// os.OpenFile flags needed to satisfy it, given an already-stat'd file.
+func osflags(fi os.FileInfo, mode p9.OpenFlags) int {
+	flags := mode.OSFlags()
+	if fi.IsDir() {
+		flags = os.O_RDONLY
+	}
+	return flags
+}
@@ -0,0 +1,104 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	"github.com/gorilla/websocket"
+)
+
+// WebsocketHandler returns an http.Handler that upgrades requests to a
+// WebSocket and hands the resulting connection to sshServer exactly as
+// if it had come in on a TCP Accept. This lets a cpud sit behind an
+// HTTPS reverse proxy or ingress that only forwards ordinary HTTP(S),
+// instead of requiring a raw TCP port to be routed to it.
+//
+// Mount it at whatever path the proxy forwards, e.g.
+// http.Handle("/cpu", server.WebsocketHandler(sshServer)).
+func WebsocketHandler(sshServer *ssh.Server) http.Handler {
+	upgrader := websocket.Upgrader{
+		// cpu sessions are not served to arbitrary web pages; same-origin
+		// checks belong to whatever is in front of this (the reverse
+		// proxy), so accept any origin here.
+		CheckOrigin: func(*http.Request) bool { return true },
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			v("CPUD:websocket upgrade: %v", err)
+			return
+		}
+		sshServer.HandleConn(newWSConn(c))
+	})
+}
+
+// wsConn adapts a *websocket.Conn, which is message-oriented, to the
+// net.Conn stream interface the ssh server expects: binary frames in
+// and out, a pong for every ping, and a ping of its own every 30
+// seconds so the connection survives intermediaries (proxies, load
+// balancers) that time out quiet TCP streams.
+type wsConn struct {
+	c       *websocket.Conn
+	readBuf []byte
+}
+
+func newWSConn(c *websocket.Conn) *wsConn {
+	c.SetPingHandler(func(string) error {
+		return c.WriteControl(websocket.PongMessage, nil, time.Now().Add(5*time.Second))
+	})
+	// WriteControl may run alongside Read/Write; it fails once the
+	// connection is closed, which ends this loop.
+	go func() {
+		t := time.NewTicker(30 * time.Second)
+		defer t.Stop()
+		for range t.C {
+			if err := c.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		}
+	}()
+	return &wsConn{c: c}
+}
+
+func (w *wsConn) Read(b []byte) (int, error) {
+	for len(w.readBuf) == 0 {
+		mt, data, err := w.c.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		if mt != websocket.BinaryMessage {
+			continue
+		}
+		w.readBuf = data
+	}
+	n := copy(b, w.readBuf)
+	w.readBuf = w.readBuf[n:]
+	return n, nil
+}
+
+func (w *wsConn) Write(b []byte) (int, error) {
+	if err := w.c.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *wsConn) Close() error         { return w.c.Close() }
+func (w *wsConn) LocalAddr() net.Addr  { return w.c.LocalAddr() }
+func (w *wsConn) RemoteAddr() net.Addr { return w.c.RemoteAddr() }
+
+func (w *wsConn) SetDeadline(t time.Time) error {
+	if err := w.c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return w.c.SetWriteDeadline(t)
+}
+
+func (w *wsConn) SetReadDeadline(t time.Time) error  { return w.c.SetReadDeadline(t) }
+func (w *wsConn) SetWriteDeadline(t time.Time) error { return w.c.SetWriteDeadline(t) }
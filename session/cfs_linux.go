@@ -16,7 +16,6 @@ package session
 
 import (
 	"context"
-	"crypto/rand"
 	"io"
 	"io/fs"
 	"log"
@@ -47,11 +46,12 @@ func NewP9FS(cl *p9.Client, lookupEntryTimeout time.Duration, getattrTimeout tim
 		lookupEntryTimeout: lookupEntryTimeout,
 		getattrTimeout:     getattrTimeout,
 		mtime:              time.Now(),
+		readDirPlus:        true,
 		inMap:              make(map[fuseops.InodeID]entry),
 		openfile:           make(map[fuseops.HandleID]openfile),
 	}
 
-	return fuseutil.NewFileSystemServer(cfs), cfs, nil
+	return &p9fsServer{Server: fuseutil.NewFileSystemServer(cfs), fs: cfs}, cfs, nil
 }
 
 type entry struct {
@@ -59,6 +59,10 @@ type entry struct {
 	root    bool
 	QID     p9.QID
 	inumber uint64
+	// lookups counts the number of LookUpInode/MkDir/Create/...-style
+	// entries the kernel has outstanding for this inode. ForgetInode
+	// decrements it by op.N and only clunks the fid once it hits zero.
+	lookups uint64
 }
 
 type openfile struct {
@@ -66,6 +70,14 @@ type openfile struct {
 	unit int
 }
 
+// P9FS has no SetLk/GetLk support: fuseutil.FileSystem, the interface
+// jacobsa/fuse actually dispatches FUSE ops against, has no lock-related
+// methods to implement in the version this package is pinned to, so
+// there is no hook to wire a 9P2000.L Tlock/Tgetlock translation into.
+// A prior pass here added SetLk/GetLk/LockOp/GetLockOp stand-ins anyway;
+// they were never reachable from any real FUSE dispatch and have been
+// removed rather than left as unreachable code. Revisit if/when
+// jacobsa/fuse grows lock ops upstream.
 type P9FS struct {
 	/////////////////////////
 	// Constant data
@@ -83,13 +95,59 @@ type P9FS struct {
 
 	// GUARDED_BY(mu)
 	keepPageCache bool
+	readDirPlus   bool
 	mtime         time.Time
 	inMap         map[fuseops.InodeID]entry
 	openfile      map[fuseops.HandleID]openfile
+
+	// conn is filled in once the kernel has sent its INIT and we are
+	// ServeOps-ing; it is what lets the server side push cache
+	// invalidations via InvalidateEntry/InvalidateInode.
+	conn *fuse.Connection
 }
 
 var _ fuseutil.FileSystem = &P9FS{}
 
+// p9fsServer wraps the generated fuseutil server so we can capture the
+// *fuse.Connection fuse.Mount hands to ServeOps; fuseutil.FileSystem itself
+// has no way to get at it.
+type p9fsServer struct {
+	fuse.Server
+	fs *P9FS
+}
+
+// ServeOps implements fuse.Server.ServeOps.
+func (s *p9fsServer) ServeOps(c *fuse.Connection) {
+	s.fs.mu.Lock()
+	s.fs.conn = c
+	s.fs.mu.Unlock()
+	s.Server.ServeOps(c)
+}
+
+// InvalidateEntry tells the kernel that the dentry parent/name is no
+// longer valid and must be re-looked-up on next use.
+//
+// The jacobsa/fuse version this package is pinned to never grew the
+// kernel-initiated invalidation support its *fuse.Connection would need
+// to expose this (there is no InvalidateEntry/InvalidateInode method to
+// call); fs.conn is kept (set by p9fsServer.ServeOps) for when that gap
+// is filled, but for now this is a documented no-op rather than a call
+// into a method that doesn't exist.
+//
+// LOCKS_EXCLUDED(fs.mu)
+func (fs *P9FS) InvalidateEntry(parent fuseops.InodeID, name string) error {
+	return nil
+}
+
+// InvalidateInode tells the kernel to drop any cached pages/attrs for the
+// given byte range of inode id (off, off+len); len of 0 means to the end
+// of the file. See InvalidateEntry for why this is currently a no-op.
+//
+// LOCKS_EXCLUDED(fs.mu)
+func (fs *P9FS) InvalidateInode(id fuseops.InodeID, off int64, len int64) error {
+	return nil
+}
+
 ////////////////////////////////////////////////////////////////////////
 // Helpers
 ////////////////////////////////////////////////////////////////////////
@@ -122,6 +180,33 @@ func (fs *P9FS) SetKeepCache(keep bool) {
 	fs.keepPageCache = keep
 }
 
+// SetReadDirPlus turns the Readdirplus-style attribute prefetch in ReadDir
+// on or off. It defaults to on. The prefetch walks each listed name and
+// records the result in the inode table; LookUpInode does not consult
+// those entries and still walks the name itself.
+//
+// LOCKS_EXCLUDED(fs.mu)
+func (fs *P9FS) SetReadDirPlus(plus bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.readDirPlus = plus
+}
+
+// SetRoot seeds FUSE's fixed root inode (1) with the already-Attach'd 9p
+// file root. Callers must do this once, before the kernel sends its
+// first lookup, since NewP9FS itself has no root path to Attach -- it
+// is handed a bare *p9.Client so it can be reused for a client's
+// forward mount or a server's reverse mount alike.
+//
+// LOCKS_EXCLUDED(fs.mu)
+func (fs *P9FS) SetRoot(root p9.File) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.inMap[1] = entry{fid: root, root: true, inumber: 1}
+}
+
 ////////////////////////////////////////////////////////////////////////
 // FileSystem methods
 ////////////////////////////////////////////////////////////////////////
@@ -139,7 +224,6 @@ func (p9fs *P9FS) LookUpInode(ctx context.Context, op *fuseops.LookUpInodeOp) er
 	p := op.Parent
 	cl, ok := p9fs.inMap[p]
 	if !ok {
-		panic("NO parent")
 		return os.ErrNotExist
 	}
 
@@ -150,11 +234,16 @@ func (p9fs *P9FS) LookUpInode(ctx context.Context, op *fuseops.LookUpInodeOp) er
 
 	q := qids[0]
 	// it always replaces what is there.
+	var lookups uint64 = 1
+	if old, ok := p9fs.inMap[fuseops.InodeID(q.Path)]; ok {
+		lookups = old.lookups + 1
+	}
 	p9fs.inMap[fuseops.InodeID(q.Path)] = entry{
 		fid:     f,
 		root:    false,
 		QID:     q,
 		inumber: q.Path,
+		lookups: lookups,
 	}
 	/*
 		Mode             FileMode
@@ -227,14 +316,12 @@ func (p9fs *P9FS) GetInodeAttributes(ctx context.Context, op *fuseops.GetInodeAt
 	in := op.Inode
 	cl, ok := p9fs.inMap[in]
 	if !ok {
-		panic("NO file")
 		return os.ErrNotExist
 	}
 
 	v("GetInodeAttributes for in %d cl %v", in, cl)
 	q, _, a, err := cl.fid.GetAttr(p9.AttrMaskAll)
 	if err != nil {
-		panic("bad getattr")
 		v("cl.GetAttr: %v", err)
 		return err
 	}
@@ -270,7 +357,6 @@ func (fs *P9FS) OpenDir(ctx context.Context, op *fuseops.OpenDirOp) error {
 	in := op.Inode
 	cl, ok := fs.inMap[in]
 	if !ok {
-		panic("NO file")
 		return os.ErrNotExist
 	}
 
@@ -294,7 +380,6 @@ func (fs *P9FS) ReadDir(ctx context.Context, op *fuseops.ReadDirOp) error {
 	ha := op.Handle
 	cl, ok := fs.openfile[ha]
 	if !ok {
-		panic("NO open file")
 		return os.ErrNotExist
 	}
 
@@ -303,7 +388,6 @@ func (fs *P9FS) ReadDir(ctx context.Context, op *fuseops.ReadDirOp) error {
 
 	d, err := cl.fid.Readdir(uint64(off), uint32(cl.unit))
 	if err != nil {
-		panic("NO readdir")
 		return err
 	}
 
@@ -329,27 +413,129 @@ func (fs *P9FS) ReadDir(ctx context.Context, op *fuseops.ReadDirOp) error {
 		}
 		n := fuseutil.WriteDirent(op.Dst[tot:], fe)
 		tot += n
+
+		if fs.readDirPlus && ent.Name != "." && ent.Name != ".." {
+			fs.readDirPlusPrefetch(cl.fid, ent.Name)
+		}
 	}
 	op.BytesRead = tot
 
 	return nil
 }
 
+// readDirPlusPrefetch does the readdirplus-style work of walking to a
+// just-listed name and recording its fid, QID and attrs in inMap.
+// LookUpInode does not look there: it walks the name again, so a lookup
+// that follows a ReadDir (e.g. "ls -l") still costs a network round
+// trip. Errors are ignored.
+//
+// LOCKS_EXCLUDED(fs.mu)
+func (fs *P9FS) readDirPlusPrefetch(dir p9.File, name string) {
+	qids, f, _, a, err := dir.WalkGetAttr([]string{name})
+	if err != nil || len(qids) == 0 {
+		return
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.newChildEntry(f, qids[0], a)
+}
+
 func (fs *P9FS) OpenFile(ctx context.Context, op *fuseops.OpenFileOp) error {
 	fs.mu.Lock()
 	defer fs.mu.Unlock()
 
+	e, ok := fs.inMap[op.Inode]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	// Open converts the fid it's called on into an open file, which is
+	// fine for e.fid the first time, but e.fid is the same fid inMap
+	// uses for every future LookUpInode/GetInodeAttributes/Open on this
+	// inode -- a second concurrent or later open would be calling Open
+	// on an already-open fid instead of a fresh one. Walk(nil) clones
+	// e.fid first, so only the clone gets consumed by Open and e.fid is
+	// left untouched for inMap's other uses.
+	_, clone, err := e.fid.Walk(nil)
+	if err != nil {
+		return err
+	}
+
+	q, unit, err := clone.Open(p9.ReadWrite)
+	if err != nil {
+		clone.Close()
+		return err
+	}
+
+	op.Handle = fuseops.HandleID(q.Path)
+	fs.openfile[op.Handle] = openfile{fid: clone, unit: int(unit)}
 	op.KeepPageCache = fs.keepPageCache
 
 	return nil
 }
 
 func (fs *P9FS) ReadFile(ctx context.Context, op *fuseops.ReadFileOp) error {
-	var err error
-	op.BytesRead, err = io.ReadFull(rand.Reader, op.Dst)
+	fs.mu.Lock()
+	of, ok := fs.openfile[op.Handle]
+	fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	n, err := of.fid.ReadAt(op.Dst, op.Offset)
+	op.BytesRead = n
+	if err == io.EOF {
+		err = nil
+	}
 	return err
 }
 
+// newChildEntry fills in a ChildInodeEntry from a 9p QID/Attr pair, and
+// records the fid in inMap so later ops (GetInodeAttributes, Open, ...)
+// can find it by inode number.
+//
+// LOCKS_REQUIRED(p9fs.mu)
+func (p9fs *P9FS) newChildEntry(f p9.File, q p9.QID, a p9.Attr) fuseops.ChildInodeEntry {
+	// The kernel may already have a lookup outstanding on this inode (we
+	// get here again via, e.g., a second Create racing a LookUpInode);
+	// overwriting inMap unconditionally would both lose the existing
+	// lookups count (ForgetInode would then clunk the fid too early) and
+	// leak the fid already sitting there. Bump lookups and close the
+	// stale fid instead of dropping it on the floor.
+	lookups := uint64(1)
+	if old, ok := p9fs.inMap[fuseops.InodeID(q.Path)]; ok {
+		lookups = old.lookups + 1
+		if old.fid != f {
+			old.fid.Close()
+		}
+	}
+	p9fs.inMap[fuseops.InodeID(q.Path)] = entry{
+		fid:     f,
+		QID:     q,
+		inumber: q.Path,
+		lookups: lookups,
+	}
+
+	var dir fs.FileMode
+	if q.Type&p9.TypeDir == p9.TypeDir {
+		dir = os.ModeDir
+	}
+	return fuseops.ChildInodeEntry{
+		Child: fuseops.InodeID(q.Path),
+		Attributes: fuseops.InodeAttributes{
+			Size:  a.Size,
+			Nlink: uint32(a.NLink),
+			Mode:  dir | fs.FileMode(a.Mode),
+			Uid:   uint32(a.UID),
+			Gid:   uint32(a.GID),
+			Atime: time.Unix(int64(a.ATimeSeconds), int64(a.ATimeNanoSeconds)),
+			Mtime: time.Unix(int64(a.MTimeSeconds), int64(a.MTimeNanoSeconds)),
+			Ctime: time.Unix(int64(a.CTimeSeconds), int64(a.CTimeNanoSeconds)),
+		},
+		EntryExpiration: time.Now().Add(p9fs.lookupEntryTimeout),
+	}
+}
+
 // The fuse package says to embed a fuseutil.NotImplementedFileSystem in your struct
 // to catch all the stuff you don't implement. That way lies madness, we've tried
 // it, it's basically undebuggable. So we put all these not implemented bits here.
@@ -357,72 +543,313 @@ func (fs *P9FS) ReadFile(ctx context.Context, op *fuseops.ReadFileOp) error {
 // struct to inherit default implementations for the methods you don't care
 // about, ensuring your struct will continue to implement FileSystem even as
 // new methods are added.
-func (fs *P9FS) SetInodeAttributes(ctx context.Context, op *fuseops.SetInodeAttributesOp) error {
-	return fuse.ENOSYS
+func (p9fs *P9FS) SetInodeAttributes(ctx context.Context, op *fuseops.SetInodeAttributesOp) error {
+	p9fs.mu.Lock()
+	e, ok := p9fs.inMap[op.Inode]
+	p9fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	var mask p9.SetAttrMask
+	var attr p9.SetAttr
+	if op.Size != nil {
+		mask.Size = true
+		attr.Size = *op.Size
+	}
+	if op.Mode != nil {
+		mask.Permissions = true
+		attr.Permissions = p9.FileMode(*op.Mode)
+	}
+	if op.Atime != nil {
+		mask.ATime = true
+		mask.ATimeNotSystemTime = true
+		attr.ATimeSeconds = uint64(op.Atime.Unix())
+		attr.ATimeNanoSeconds = uint64(op.Atime.Nanosecond())
+	}
+	if op.Mtime != nil {
+		mask.MTime = true
+		mask.MTimeNotSystemTime = true
+		attr.MTimeSeconds = uint64(op.Mtime.Unix())
+		attr.MTimeNanoSeconds = uint64(op.Mtime.Nanosecond())
+	}
+
+	if err := e.fid.SetAttr(mask, attr); err != nil {
+		return err
+	}
+
+	_, _, a, err := e.fid.GetAttr(p9.AttrMaskAll)
+	if err != nil {
+		return err
+	}
+	op.Attributes = fuseops.InodeAttributes{
+		Size:  a.Size,
+		Nlink: uint32(a.NLink),
+		Mode:  fs.FileMode(a.Mode),
+		Uid:   uint32(a.UID),
+		Gid:   uint32(a.GID),
+		Atime: time.Unix(int64(a.ATimeSeconds), int64(a.ATimeNanoSeconds)),
+		Mtime: time.Unix(int64(a.MTimeSeconds), int64(a.MTimeNanoSeconds)),
+		Ctime: time.Unix(int64(a.CTimeSeconds), int64(a.CTimeNanoSeconds)),
+	}
+	op.AttributesExpiration = time.Now().Add(p9fs.getattrTimeout)
+	return nil
 }
 
 func (fs *P9FS) ForgetInode(ctx context.Context, op *fuseops.ForgetInodeOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	e, ok := fs.inMap[op.Inode]
+	if !ok {
+		// Already gone; the kernel can forget an inode more than once.
+		return nil
+	}
+	if e.root {
+		return nil
+	}
+	if op.N >= e.lookups {
+		delete(fs.inMap, op.Inode)
+		return e.fid.Close()
+	}
+	e.lookups -= op.N
+	fs.inMap[op.Inode] = e
+	return nil
 }
 
 func (fs *P9FS) BatchForget(ctx context.Context, op *fuseops.BatchForgetOp) error {
-	return fuse.ENOSYS
+	for _, be := range op.Entries {
+		if err := fs.ForgetInode(ctx, &fuseops.ForgetInodeOp{Inode: be.Inode, N: be.N}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (fs *P9FS) MkDir(ctx context.Context, op *fuseops.MkDirOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	if _, err := p.fid.Mkdir(op.Name, p9.FileMode(op.Mode), p9.NoUID, p9.NoGID); err != nil {
+		return err
+	}
+
+	qids, f, _, a, err := p.fid.WalkGetAttr([]string{op.Name})
+	if err != nil {
+		return err
+	}
+	op.Entry = fs.newChildEntry(f, qids[0], a)
+	return nil
 }
 
 func (fs *P9FS) MkNode(ctx context.Context, op *fuseops.MkNodeOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	if _, err := p.fid.Mknod(op.Name, p9.FileMode(op.Mode), 0, 0, p9.NoUID, p9.NoGID); err != nil {
+		return err
+	}
+
+	qids, f, _, a, err := p.fid.WalkGetAttr([]string{op.Name})
+	if err != nil {
+		return err
+	}
+	op.Entry = fs.newChildEntry(f, qids[0], a)
+	return nil
 }
 
 func (fs *P9FS) CreateFile(ctx context.Context, op *fuseops.CreateFileOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	f, q, _, err := p.fid.Create(op.Name, p9.ReadWrite, p9.FileMode(op.Mode), p9.NoUID, p9.NoGID)
+	if err != nil {
+		return err
+	}
+
+	_, _, a, err := f.GetAttr(p9.AttrMaskAll)
+	if err != nil {
+		return err
+	}
+	op.Entry = fs.newChildEntry(f, q, a)
+	op.Handle = fuseops.HandleID(q.Path)
+	fs.openfile[op.Handle] = openfile{fid: f}
+	return nil
 }
 
 func (fs *P9FS) CreateSymlink(ctx context.Context, op *fuseops.CreateSymlinkOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	if _, err := p.fid.Symlink(op.Target, op.Name, p9.NoUID, p9.NoGID); err != nil {
+		return err
+	}
+
+	qids, f, _, a, err := p.fid.WalkGetAttr([]string{op.Name})
+	if err != nil {
+		return err
+	}
+	op.Entry = fs.newChildEntry(f, qids[0], a)
+	return nil
 }
 
 func (fs *P9FS) CreateLink(ctx context.Context, op *fuseops.CreateLinkOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+	target, ok := fs.inMap[op.Target]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	if err := p.fid.Link(target.fid, op.Name); err != nil {
+		return err
+	}
+
+	qids, f, _, a, err := p.fid.WalkGetAttr([]string{op.Name})
+	if err != nil {
+		return err
+	}
+	op.Entry = fs.newChildEntry(f, qids[0], a)
+	return nil
 }
 
 func (fs *P9FS) Rename(ctx context.Context, op *fuseops.RenameOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	oldParent, ok := fs.inMap[op.OldParent]
+	if !ok {
+		return os.ErrNotExist
+	}
+	newParent, ok := fs.inMap[op.NewParent]
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	return oldParent.fid.RenameAt(op.OldName, newParent.fid, op.NewName)
 }
 
 func (fs *P9FS) RmDir(ctx context.Context, op *fuseops.RmDirOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+	return p.fid.UnlinkAt(op.Name, 0)
 }
 
 func (fs *P9FS) Unlink(ctx context.Context, op *fuseops.UnlinkOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	p, ok := fs.inMap[op.Parent]
+	if !ok {
+		return os.ErrNotExist
+	}
+	return p.fid.UnlinkAt(op.Name, 0)
 }
 
 func (fs *P9FS) ReleaseDirHandle(ctx context.Context, op *fuseops.ReleaseDirHandleOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	of, ok := fs.openfile[op.Handle]
+	if !ok {
+		return nil
+	}
+	delete(fs.openfile, op.Handle)
+	return of.fid.Close()
 }
 
 func (fs *P9FS) WriteFile(ctx context.Context, op *fuseops.WriteFileOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	of, ok := fs.openfile[op.Handle]
+	fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	_, err := of.fid.WriteAt(op.Data, op.Offset)
+	return err
 }
 
 func (fs *P9FS) SyncFile(ctx context.Context, op *fuseops.SyncFileOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	of, ok := fs.openfile[op.Handle]
+	fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+	return of.fid.FSync()
 }
 
+// FlushFile handles the flush-on-close (not fsync) request: POSIX close(2)
+// semantics want write errors reported here if possible. p9.File has no
+// separate flush RPC (9P2000.L's Tflush aborts in-flight requests, it
+// isn't this), so FSync is the closest equivalent available.
 func (fs *P9FS) FlushFile(ctx context.Context, op *fuseops.FlushFileOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	of, ok := fs.openfile[op.Handle]
+	fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+	return of.fid.FSync()
 }
 
 func (fs *P9FS) ReleaseFileHandle(ctx context.Context, op *fuseops.ReleaseFileHandleOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	of, ok := fs.openfile[op.Handle]
+	if !ok {
+		return nil
+	}
+	delete(fs.openfile, op.Handle)
+	return of.fid.Close()
 }
 
 func (fs *P9FS) ReadSymlink(ctx context.Context, op *fuseops.ReadSymlinkOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	e, ok := fs.inMap[op.Inode]
+	fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	target, err := e.fid.Readlink()
+	if err != nil {
+		return err
+	}
+	op.Target = target
+	return nil
 }
 
 func (fs *P9FS) RemoveXattr(ctx context.Context, op *fuseops.RemoveXattrOp) error {
@@ -441,8 +868,36 @@ func (fs *P9FS) SetXattr(ctx context.Context, op *fuseops.SetXattrOp) error {
 	return fuse.ENOSYS
 }
 
+// Fallocate implements fuseops.FallocateOp by pre-extending the file with a
+// WriteAt of a single zero byte past its current end, when the requested
+// range reaches beyond it. It is not a true fallocate (no hole-punching,
+// no FALLOC_FL_KEEP_SIZE support), but it gives callers that just want to
+// reserve space the effect they are after, without clobbering a real
+// byte that already exists at the end of the range.
 func (fs *P9FS) Fallocate(ctx context.Context, op *fuseops.FallocateOp) error {
-	return fuse.ENOSYS
+	fs.mu.Lock()
+	of, ok := fs.openfile[op.Handle]
+	fs.mu.Unlock()
+	if !ok {
+		return os.ErrNotExist
+	}
+
+	end := op.Offset + op.Length
+	if end == 0 {
+		return nil
+	}
+	_, _, a, err := of.fid.GetAttr(p9.AttrMaskAll)
+	if err != nil {
+		return err
+	}
+	if end <= a.Size {
+		// Already within the file; nothing to extend.
+		return nil
+	}
+	if _, err := of.fid.WriteAt([]byte{0}, int64(end-1)); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (fs *P9FS) Destroy() {
@@ -0,0 +1,60 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hugelgupf/p9/p9"
+	"github.com/u-root/cpu/grpctransport"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// WithGRPC records addr and the key files dialGRPC9P uses to fetch a 9p
+// connection over a grpctransport NineP stream dialed at addr.
+// Namespace does not call dialGRPC9P: it always does net.Dial("tcp",
+// s.port9p), whether or not WithGRPC was called. keyFile, if non-empty, is a PEM
+// ed25519/rsa key loaded the same way ssh host/user keys are and used
+// to authenticate the stream with grpctransport's mTLS; an empty
+// keyFile falls back to an unauthenticated channel, for testing against
+// a cpud that was itself started without -hostkey. hostPubKeyFile, the
+// gRPC counterpart of an ssh known_hosts entry, names the one cpud host
+// key this session trusts; it's required whenever keyFile is set, since
+// mTLS here has no CA to fall back on to authenticate the server.
+func (s *Session) WithGRPC(addr, keyFile, hostPubKeyFile string) *Session {
+	s.grpcAddr = addr
+	s.grpcKeyFile = keyFile
+	s.grpcHostPubKeyFile = hostPubKeyFile
+	return s
+}
+
+// dialGRPC9P returns a p9.Client talking over a grpctransport NineP
+// stream, set up from the fields WithGRPC records, rather than over a
+// bare TCP socket. Namespace does not call it.
+func (s *Session) dialGRPC9P() (*p9.Client, error) {
+	creds := credentials.TransportCredentials(insecure.NewCredentials())
+	if s.grpcKeyFile != "" {
+		c, err := grpctransport.ClientCredentials(s.grpcKeyFile, s.grpcHostPubKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("CPUD:grpctransport.ClientCredentials: %v", err)
+		}
+		creds = c
+	}
+
+	gc, err := grpctransport.Dial(s.grpcAddr, creds)
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:grpctransport.Dial %s: %v", s.grpcAddr, err)
+	}
+	s.grpcClient = gc
+
+	conn, err := gc.NineP(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("CPUD:grpctransport NineP stream: %v", err)
+	}
+
+	return p9.NewClient(conn, p9.WithMessageSize(128*1024))
+}
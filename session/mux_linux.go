@@ -0,0 +1,79 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"net"
+
+	"github.com/soheilhy/cmux"
+)
+
+// NinePMagic is the four-byte preamble a client must send before the
+// first 9P2000.L message when dialing a Muxer's single port. Plain 9P
+// has no self-announcing preamble the way SSH ("SSH-2.0-...") and
+// HTTP/2 ("PRI * HTTP/2.0...") do, so cpu invents one rather than
+// trying to sniff a raw Tversion size prefix, which collides too
+// easily with the first bytes of other protocols.
+const NinePMagic = "9P2K"
+
+// Muxer sniffs the first bytes of every connection accepted on one
+// listener and routes it to cpud's SSH, gRPC, or 9P transport, so
+// operators only need to open a single TCP port instead of the three
+// -sp/-addr/-port9p used to need.
+type Muxer struct {
+	cm cmux.CMux
+}
+
+// NewMuxer wraps l for multiplexing. l must not be used directly
+// again; read from the sub-listeners returned by MatchSSH, MatchGRPC,
+// and Match9P instead, then call Serve once all three have a consumer
+// running an Accept loop.
+func NewMuxer(l net.Listener) *Muxer {
+	return &Muxer{cm: cmux.New(l)}
+}
+
+// MatchSSH returns the sub-listener for connections that open with an
+// SSH version banner, for handing to the existing gliderlabs/ssh
+// server (server.New).
+func (m *Muxer) MatchSSH() net.Listener {
+	return m.cm.Match(cmux.PrefixMatcher("SSH-"))
+}
+
+// MatchGRPC returns the sub-listener for connections that open with
+// an HTTP/2 client preface, for handing to a grpc.Server.
+func (m *Muxer) MatchGRPC() net.Listener {
+	return m.cm.Match(cmux.HTTP2())
+}
+
+// Match9P returns the sub-listener for connections that open with
+// NinePMagic, for handing to a p9.Server (e.g. via server.ServeGofer).
+func (m *Muxer) Match9P() net.Listener {
+	return m.cm.Match(cmux.PrefixMatcher(NinePMagic))
+}
+
+// DialNinePMux dials network/addr and writes NinePMagic before
+// returning the connection, so a raw 9P2000.L client (a gofer consumer
+// talking to a Muxer's single port, as opposed to a kernel v9fs mount,
+// which is never multiplexed this way) lands on the Match9P
+// sub-listener instead of being rejected as an unrecognized protocol.
+func DialNinePMux(network, addr string) (net.Conn, error) {
+	c, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Write([]byte(NinePMagic)); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Serve runs the underlying cmux's accept loop, dispatching each new
+// connection to whichever sub-listener's matcher claims it. It blocks
+// until the root listener closes or a non-recoverable error occurs,
+// same as any cmux.CMux.
+func (m *Muxer) Serve() error {
+	return m.cm.Serve()
+}
@@ -0,0 +1,221 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// ociSeq makes runOCI's container id unique across concurrent sessions
+// in the same cpud process; os.Getpid() alone is the same for all of
+// them and collides the moment a second session starts while the
+// first's container is still up.
+var ociSeq int64
+
+// Resources are the cgroup v2 limits runc should apply to the container a
+// Session spawns. A zero value in any field means "don't set a limit",
+// matching runc/OCI's own convention of omitting the key.
+type Resources struct {
+	// CPUQuota and CPUPeriod are microseconds, same units as
+	// cgroup cpu.max; CPUQuota/CPUPeriod is the fraction of a CPU
+	// the container may use.
+	CPUQuota, CPUPeriod int64
+	// MemoryLimit is a byte count, same as cgroup memory.max.
+	MemoryLimit int64
+	// PidsLimit is the max number of tasks, same as cgroup pids.max.
+	PidsLimit int64
+}
+
+// WithResources arranges for Run to apply r as cgroup limits on the OCI
+// container it spawns. It only has an effect once WithOCI has also been
+// called; plain exec.Command sessions have no cgroup of their own to limit.
+func (s *Session) WithResources(r Resources) *Session {
+	s.resources = r
+	return s
+}
+
+// WithOCI arranges for Run to spawn the session's command inside a runc
+// container rooted at the 9p/FUSE mount set up by Namespace, instead of a
+// bare exec.Command in an unshared mount namespace. This gets us proper
+// cgroup accounting, seccomp/apparmor confinement, and uid mapping, at
+// the cost of depending on a runc binary being present on the cpud host.
+func (s *Session) WithOCI(runcPath string) *Session {
+	if runcPath == "" {
+		runcPath = "runc"
+	}
+	s.runc = runcPath
+	return s
+}
+
+// ociConfig is the small subset of the OCI runtime-spec config.json we
+// need to hand runc: a rootfs, a process to run, any extra binds, and
+// the cgroup limits translated from Resources. It is not the full spec;
+// runc fills in sane defaults (namespaces, default mounts, capabilities)
+// for everything we leave out.
+type ociConfig struct {
+	OCIVersion string     `json:"ociVersion"`
+	Root       ociRoot    `json:"root"`
+	Process    ociProcess `json:"process"`
+	Hostname   string     `json:"hostname,omitempty"`
+	Mounts     []ociMount `json:"mounts,omitempty"`
+	Linux      *ociLinux  `json:"linux,omitempty"`
+}
+
+type ociRoot struct {
+	Path     string `json:"path"`
+	Readonly bool   `json:"readonly"`
+}
+
+type ociProcess struct {
+	Terminal bool     `json:"terminal"`
+	User     ociUser  `json:"user"`
+	Args     []string `json:"args"`
+	Env      []string `json:"env,omitempty"`
+	Cwd      string   `json:"cwd"`
+}
+
+type ociUser struct {
+	UID uint32 `json:"uid"`
+	GID uint32 `json:"gid"`
+}
+
+type ociMount struct {
+	Destination string   `json:"destination"`
+	Source      string   `json:"source"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type ociLinux struct {
+	Resources *ociResources `json:"resources,omitempty"`
+}
+
+type ociResources struct {
+	CPU    *ociCPU    `json:"cpu,omitempty"`
+	Memory *ociMemory `json:"memory,omitempty"`
+	Pids   *ociPids   `json:"pids,omitempty"`
+}
+
+type ociCPU struct {
+	Quota  int64 `json:"quota,omitempty"`
+	Period int64 `json:"period,omitempty"`
+}
+
+type ociMemory struct {
+	Limit int64 `json:"limit,omitempty"`
+}
+
+type ociPids struct {
+	Limit int64 `json:"limit,omitempty"`
+}
+
+// ociState tracks the bundle directory and container id of a session's
+// runc container, so Close can tear it down and Run can runc exec
+// follow-up commands into an already-running one.
+type ociState struct {
+	bundle string
+	id     string
+}
+
+// runOCI builds an OCI bundle rooted at mountTarget (the 9p/FUSE mount
+// Namespace already set up) and runs args inside it via runc. It is the
+// WithOCI alternative to the plain exec.Command path in Run.
+func (s *Session) runOCI(mountTarget string, args []string) error {
+	bundle, err := os.MkdirTemp("", "cpu-oci-")
+	if err != nil {
+		return fmt.Errorf("CPUD:oci bundle dir: %v", err)
+	}
+
+	cfg := ociConfig{
+		OCIVersion: "1.0.2",
+		Root:       ociRoot{Path: mountTarget},
+		Process: ociProcess{
+			Args: args,
+			Env:  os.Environ(),
+			Cwd:  "/",
+			User: ociUser{UID: uint32(os.Getuid()), GID: uint32(os.Getgid())},
+		},
+	}
+	for _, n := range s.binds {
+		cfg.Mounts = append(cfg.Mounts, ociMount{
+			Destination: n.Local,
+			Source:      filepath.Join(mountTarget, n.Remote),
+			Options:     []string{"bind"},
+		})
+	}
+	if r := s.resources; r.CPUQuota != 0 || r.CPUPeriod != 0 || r.MemoryLimit != 0 || r.PidsLimit != 0 {
+		res := &ociResources{}
+		if r.CPUQuota != 0 || r.CPUPeriod != 0 {
+			res.CPU = &ociCPU{Quota: r.CPUQuota, Period: r.CPUPeriod}
+		}
+		if r.MemoryLimit != 0 {
+			res.Memory = &ociMemory{Limit: r.MemoryLimit}
+		}
+		if r.PidsLimit != 0 {
+			res.Pids = &ociPids{Limit: r.PidsLimit}
+		}
+		cfg.Linux = &ociLinux{Resources: res}
+	}
+
+	b, err := json.MarshalIndent(&cfg, "", "\t")
+	if err != nil {
+		return fmt.Errorf("CPUD:marshal oci config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "config.json"), b, 0o644); err != nil {
+		return fmt.Errorf("CPUD:write config.json: %v", err)
+	}
+
+	id := fmt.Sprintf("cpu-%d-%d", os.Getpid(), atomic.AddInt64(&ociSeq, 1))
+	s.oci = &ociState{bundle: bundle, id: id}
+	verbose("CPUD: runc run %s in %s", id, bundle)
+
+	runc := s.runc
+	if runc == "" {
+		runc = "runc"
+	}
+	c := exec.Command(runc, "run", "--bundle", bundle, id)
+	c.Stdin, c.Stdout, c.Stderr = s.Stdin, s.Stdout, s.Stderr
+	return c.Run()
+}
+
+// ociExec runs args inside an already-running OCI container via runc
+// exec, for any command after the first in a session that was started
+// with WithOCI.
+func (s *Session) ociExec(args []string) error {
+	if s.oci == nil {
+		return fmt.Errorf("CPUD:ociExec: no running container for this session")
+	}
+	runc := s.runc
+	if runc == "" {
+		runc = "runc"
+	}
+	a := append([]string{"exec", s.oci.id}, args...)
+	c := exec.Command(runc, a...)
+	c.Stdin, c.Stdout, c.Stderr = s.Stdin, s.Stdout, s.Stderr
+	return c.Run()
+}
+
+// ociClose tears down the container and its bundle directory, if Run
+// ever set one up via WithOCI. It is called from Session.Close.
+func (s *Session) ociClose() error {
+	if s.oci == nil {
+		return nil
+	}
+	runc := s.runc
+	if runc == "" {
+		runc = "runc"
+	}
+	var err error
+	if out, e := exec.Command(runc, "delete", "--force", s.oci.id).CombinedOutput(); e != nil {
+		err = fmt.Errorf("CPUD:runc delete %s: %v: %s", s.oci.id, e, out)
+	}
+	os.RemoveAll(s.oci.bundle)
+	s.oci = nil
+	return err
+}
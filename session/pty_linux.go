@@ -0,0 +1,77 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"syscall"
+
+	"github.com/creack/pty"
+)
+
+// WithPTY arranges for Run to allocate a pseudoterminal for the spawned
+// command and make it the command's controlling tty, instead of the
+// plain stdin/stdout/stderr pipes Run otherwise uses. rows/cols/term
+// seed the initial window size and $TERM; a zero rows/cols is left for
+// the kernel default.
+func (s *Session) WithPTY(rows, cols uint16, term string) *Session {
+	s.pty = true
+	s.rows, s.cols = rows, cols
+	s.term = term
+	return s
+}
+
+// Resize changes the window size of the session's pty, if one was
+// allocated by WithPTY. It is safe to call from the goroutine watching
+// for SIGWINCH/window-change messages.
+func (s *Session) Resize(rows, cols uint16) error {
+	s.ptyMu.Lock()
+	defer s.ptyMu.Unlock()
+	if s.ptyFile == nil {
+		return nil
+	}
+	return pty.Setsize(s.ptyFile, &pty.Winsize{Rows: rows, Cols: cols})
+}
+
+// startPTY starts c with a freshly allocated pty as its controlling
+// terminal, wires the pty master to s.Stdin/s.Stdout (s.Stderr is not
+// separately addressable once a pty is in play, same as a real
+// terminal), and records the master so Resize can reach it later.
+func (s *Session) startPTY(c *exec.Cmd) error {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.Setsid = true
+	c.SysProcAttr.Setctty = true
+
+	if s.term != "" {
+		c.Env = append(c.Env, fmt.Sprintf("TERM=%s", s.term))
+	}
+
+	f, err := pty.Start(c)
+	if err != nil {
+		return fmt.Errorf("CPUD:pty.Start: %v", err)
+	}
+	if s.rows != 0 || s.cols != 0 {
+		if err := pty.Setsize(f, &pty.Winsize{Rows: s.rows, Cols: s.cols}); err != nil {
+			verbose("CPUD:initial Setsize: %v", err)
+		}
+	}
+
+	s.ptyMu.Lock()
+	s.ptyFile = f
+	s.ptyMu.Unlock()
+
+	go io.Copy(f, s.Stdin) //nolint:errcheck
+	io.Copy(s.Stdout, f)   //nolint:errcheck
+
+	// Reading the master fails once the slave side hangs up. Close it
+	// and reap the command without waiting on the stdin copy, which has
+	// nothing further to report.
+	f.Close()
+	return c.Wait()
+}
@@ -0,0 +1,191 @@
+// Copyright 2018-2022 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package session
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/hugelgupf/p9/p9"
+	"github.com/jacobsa/fuse"
+	"github.com/u-root/cpu/grpctransport"
+)
+
+// Bind defines a bind mount. It records the Local directory, e.g. /bin,
+// and the remote directory, e.g. /tmp/cpu/bin, the same way
+// server.Bind does for the other (ssh+9p) side of a cpu session.
+type Bind struct {
+	Local  string
+	Remote string
+}
+
+// Session is one instance of a cpud-driven remote command: Namespace
+// mounts the namespace a client exported (over a TCP 9p connection,
+// either directly or via FUSE; WithGRPC does not change this), and Run
+// then execs cmd/args against it, optionally under a
+// pty (WithPTY) or inside an OCI container (WithOCI).
+//
+// It is the gcpud/cpuns counterpart of server.Session: server.Session
+// is driven by an incoming ssh channel, where this one is driven
+// directly by a command line (cpuns) or by gcpud's gRPC transport.
+type Session struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// MaxWrite and MaxPages are the 9p/FUSE transfer-size knobs
+	// described on defaultMaxWrite; zero means use defaultMaxWrite. The
+	// FUSE mount reads only MaxWrite.
+	MaxWrite int
+	MaxPages int
+
+	cmd  string
+	args []string
+
+	binds  []Bind
+	fail   bool
+	msize  int
+	mopts  string
+	port9p string
+	tmpMnt string
+
+	// pty/rows/cols/term seed startPTY; ptyMu guards ptyFile, which
+	// Resize needs to reach from whatever goroutine is watching for
+	// window-change notifications.
+	pty        bool
+	rows, cols uint16
+	term       string
+	ptyMu      sync.Mutex
+	ptyFile    *os.File
+
+	// resources/runc/oci back WithResources/WithOCI: runc is set once
+	// WithOCI is called, and oci is filled in by runOCI once the
+	// container is actually started.
+	resources Resources
+	runc      string
+	oci       *ociState
+
+	// grpcAddr/grpcKeyFile/grpcHostPubKeyFile/grpcClient are set by
+	// WithGRPC and dialGRPC9P; Namespace does not use them.
+	grpcAddr           string
+	grpcKeyFile        string
+	grpcHostPubKeyFile string
+	grpcClient         *grpctransport.Client
+
+	// cl/root/fs/cfs/mfs are filled in by Namespace's FUSE (CPUD_FUSE)
+	// path; Close tears mfs down again.
+	cl   *p9.Client
+	root p9.File
+	fs   fuse.Server
+	cfs  *P9FS
+	mfs  *fuse.MountedFileSystem
+}
+
+// New returns a Session that will run cmd with args once started via
+// Run. port9p is the loopback port Namespace dials if CPUNONCE is set
+// in the environment (cpud's -remote handler sets it before running
+// this package; cpuns, run directly instead of over ssh, passes "" and
+// Namespace becomes a no-op). tmpMnt, the directory under which
+// Namespace mounts the 9p/FUSE namespace and layers binds, defaults to
+// $CPU_TMPMNT, falling back to /tmp if that's unset; WithTmpMnt
+// overrides it.
+func New(port9p, cmd string, args ...string) *Session {
+	tmpMnt := os.Getenv("CPU_TMPMNT")
+	if tmpMnt == "" {
+		tmpMnt = "/tmp"
+	}
+	return &Session{
+		port9p: port9p,
+		tmpMnt: tmpMnt,
+		cmd:    cmd,
+		args:   args,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// WithTmpMnt overrides the directory under which Namespace mounts the
+// 9p/FUSE namespace and layers binds, in place of New's $CPU_TMPMNT/tmp
+// default.
+func (s *Session) WithTmpMnt(dir string) *Session {
+	s.tmpMnt = dir
+	return s
+}
+
+// WithBinds arranges for Namespace to bind mount each Bind's Remote
+// path (relative to the mounted namespace) onto its Local path once
+// the namespace is up.
+func (s *Session) WithBinds(binds []Bind) *Session {
+	s.binds = binds
+	return s
+}
+
+// Run starts cmd/args against the namespace Namespace set up (if
+// CPUNONCE was present in the environment), under a pty if WithPTY was
+// called, or inside an OCI container if WithOCI was called, and waits
+// for it to exit.
+func (s *Session) Run() error {
+	if err := runSetup(s.tmpMnt); err != nil {
+		return err
+	}
+	w, err := s.Namespace()
+	if err != nil {
+		return err
+	}
+	if w != nil {
+		verbose("CPUD:Namespace warning: %v", w)
+	}
+
+	if s.runc != "" {
+		if s.oci != nil {
+			return s.ociExec(append([]string{s.cmd}, s.args...))
+		}
+		return s.runOCI(filepath.Join(s.tmpMnt, "cpu"), append([]string{s.cmd}, s.args...))
+	}
+
+	c := exec.Command(s.cmd, s.args...)
+	c.Stdin, c.Stdout, c.Stderr = s.Stdin, s.Stdout, s.Stderr
+	if s.pty {
+		return s.startPTY(c)
+	}
+	return c.Run()
+}
+
+// Close tears down whatever Run set up on top of the plain exec path:
+// an OCI container (WithOCI), and the FUSE-backed 9p mount, if the
+// FUSE gateway (CPUD_FUSE) rather than the kernel 9p client handled
+// Namespace.
+func (s *Session) Close() error {
+	err := s.ociClose()
+	if s.mfs != nil {
+		if uerr := fuse.Unmount(s.mfs.Dir()); uerr != nil && err == nil {
+			err = uerr
+		}
+	}
+	return err
+}
+
+var (
+	// v is this package's debug logger; it defaults to discarding
+	// everything, same as server's -d-gated logging, since Namespace
+	// and the OCI/pty helpers are chatty. SetVerbose overrides it.
+	v = func(string, ...interface{}) {}
+)
+
+// SetVerbose installs f as the package's debug logger, so a caller
+// that already has its own "-d"-gated, prefixed log function (e.g.
+// gcpud's cpud) can route this package's verbose output through it
+// instead of getting cpu's own formatting.
+func SetVerbose(f func(string, ...interface{})) {
+	v = f
+}
+
+func verbose(f string, a ...interface{}) {
+	v(f, a...)
+}
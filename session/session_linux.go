@@ -21,6 +21,12 @@ import (
 
 var FUSE bool
 
+// defaultMaxWrite is the traditional v9fs/FUSE transfer size. Callers that
+// want the kernel's larger CAP_MAX_PAGES transfers (up to ~1 MiB per
+// request instead of 128 KiB) should set Session.MaxWrite/MaxPages
+// before calling Namespace.
+const defaultMaxWrite = 128 * 1024
+
 // Namespace assembles a NameSpace for this cpud, iff CPU_NONCE
 // is set and len(s.binds) > 0.
 //
@@ -117,13 +123,17 @@ func (s *Session) Namespace() (error, error) {
 		s.cl = cl
 		s.root = root
 
-		fs, cfs, err := NewP9FS(cl, root, 5*time.Second, 5*time.Second)
+		fs, cfs, err := NewP9FS(cl, 5*time.Second, 5*time.Second)
 		if err != nil {
 			return nil, err
 		}
 
 		s.fs = fs
 		s.cfs = cfs
+		maxWrite := s.MaxWrite
+		if maxWrite == 0 {
+			maxWrite = defaultMaxWrite
+		}
 		// This will need to move to the kernel-independent part at some point.
 		c := &fuse.MountConfig{
 			ErrorLogger: log.Default(),
@@ -132,7 +142,16 @@ func (s *Session) Namespace() (error, error) {
 			// fuse: Bad value for 'source'
 			// and the mount will fail
 			FSName: "cpud",
+			Options: map[string]string{
+				"subtype":  "cpu9p",
+				"max_read": fmt.Sprintf("%d", maxWrite),
+			},
 		}
+		// fuse.Mount already falls back to the fusermount3/fusermount
+		// setuid helper on its own if a direct mount(2) comes back
+		// EPERM (no /dev/fuse permissions, or FUSE run as non-root
+		// without user_allow_other); driving fusermount3 ourselves
+		// here as well would just race it for the same mount point.
 		mfs, err := fuse.Mount(mountTarget, fs, c)
 		if err != nil {
 			return nil, err
@@ -140,9 +159,9 @@ func (s *Session) Namespace() (error, error) {
 		s.mfs = mfs
 		// annoying but clean up later.
 		s.cfs.inMap[1] = entry{
-			fid:  root,
-			root: true,
-			ino:  1,
+			fid:     root,
+			root:    true,
+			inumber: 1,
 		}
 	} else {
 		verbose("CPUD: using 9P")
@@ -153,10 +172,23 @@ func (s *Session) Namespace() (error, error) {
 		fd := cf.Fd()
 		verbose("CPUD:fd is %v", fd)
 
+		// msize governs the biggest single 9p Tread/Twrite; MaxWrite and
+		// MaxPages (CAP_MAX_PAGES worth of page-size transfers) are both
+		// just alternate ways callers can ask for a bigger one.
+		msize := s.msize
+		if msize == 0 {
+			msize = defaultMaxWrite
+		}
+		if s.MaxWrite > msize {
+			msize = s.MaxWrite
+		}
+		if fromPages := s.MaxPages * unix.Getpagesize(); fromPages > msize {
+			msize = fromPages
+		}
 		// The debug= option is here so you can see how to temporarily set it if needed.
 		// It generates copious output so use it sparingly.
 		// A useful compromise value is 5.
-		opts := fmt.Sprintf("version=9p2000.L,trans=fd,rfdno=%d,wfdno=%d,uname=%v,debug=0,msize=%d", fd, fd, user, s.msize)
+		opts := fmt.Sprintf("version=9p2000.L,trans=fd,rfdno=%d,wfdno=%d,uname=%v,debug=0,msize=%d", fd, fd, user, msize)
 		if len(s.mopts) > 0 {
 			opts += "," + s.mopts
 		}